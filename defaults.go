@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// defaultValueTag is the struct tag that holds the default value of a field.
+const defaultValueTag = "default"
+
+// tagDefaultsOrigin is the origin of the default values applied by Unmarshal.
+const tagDefaultsOrigin = "default tag"
+
+// AddDefaultsFromStruct adds the default values specified by the `default`
+// struct tags of the type T as a default record at the key.  Since the values
+// are part of the configuration tree they are included in the output of
+// [Config.Explain] and [Config.Marshal], and they only need to be specified
+// in one place.
+//
+// The record is named after the type, for example 'defaults: main.Config'.
+//
+// See [Config.Unmarshal] for details about the `default` struct tag.
+//
+// To place the configuration at the root use `goschtalt.Root` ([Root]) instead
+// of "" for more clarity.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [ValueOption]
+//   - [UnmarshalValueOption]
+func AddDefaultsFromStruct[T any](key string, opts ...ValueOption) Option {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil {
+		t = reflect.TypeOf((*T)(nil)).Elem()
+	}
+
+	return &value{
+		text: print.P("AddDefaultsFromStruct",
+			print.String(t.String()), print.String(key), print.LiteralStringers(opts)),
+		recordName: "defaults: " + t.String(),
+		key:        key,
+		getter:     tagDefaultsGetter{t: t},
+		opts:       append([]ValueOption{AsDefault()}, opts...),
+	}
+}
+
+// tagDefaultsGetter provides the default values found in the struct tags of
+// the type.
+type tagDefaultsGetter struct {
+	t       reflect.Type
+	tagName string
+}
+
+func (g tagDefaultsGetter) Get(string, Unmarshaler) (any, error) {
+	tagName := g.tagName
+	if tagName == "" {
+		tagName = defaultTag
+	}
+
+	td := tagDefaults{
+		tagName: tagName,
+		mapper:  func(s string) string { return s },
+	}
+
+	// Return an untyped nil so no record is created.
+	if m := td.build(g.t); m != nil {
+		return m, nil
+	}
+	return nil, nil
+}
+
+// tagDefaults collects the default values specified by the `default` struct
+// tags into a tree of configuration keys.
+type tagDefaults struct {
+	tagName string
+	squash  bool
+	mapper  func(string) string
+	seen    map[reflect.Type]bool
+}
+
+// build returns the default values of the type or nil if there are none.
+func (td *tagDefaults) build(t reflect.Type) map[string]any {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// Recursive types would never end.
+	if td.seen == nil {
+		td.seen = make(map[reflect.Type]bool)
+	}
+	if td.seen[t] {
+		return nil
+	}
+	td.seen[t] = true
+	defer delete(td.seen, t)
+
+	rv := make(map[string]any)
+	td.addFields(rv, t)
+
+	if len(rv) == 0 {
+		return nil
+	}
+	return rv
+}
+
+func (td *tagDefaults) addFields(m map[string]any, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		tag := strings.Split(sf.Tag.Get(td.tagName), ",")
+		name, opts := tag[0], tag[1:]
+
+		squash := td.squash && sf.Anonymous
+		var remain bool
+		for _, opt := range opts {
+			switch opt {
+			case "squash":
+				squash = true
+			case "remain":
+				remain = true
+			}
+		}
+
+		if remain {
+			continue
+		}
+
+		if squash {
+			ft := sf.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && !td.seen[ft] {
+				td.seen[ft] = true
+				td.addFields(m, ft)
+				delete(td.seen, ft)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		key := td.mapper(name)
+		if key == "-" {
+			continue
+		}
+
+		if text, found := sf.Tag.Lookup(defaultValueTag); found {
+			m[key] = defaultValue(sf.Type, text)
+			continue
+		}
+
+		if sub := td.build(sf.Type); sub != nil {
+			m[key] = sub
+		}
+	}
+}
+
+// defaultValue converts the text into the kind of the type if possible, so
+// adapters are not needed for the basic types.  Otherwise the text is used as
+// is and is converted by the adapters like any other configuration value.
+func defaultValue(t reflect.Type, text string) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if i, err := strconv.ParseInt(text, 0, t.Bits()); err == nil {
+			return i
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if u, err := strconv.ParseUint(text, 0, t.Bits()); err == nil {
+			return u
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := strconv.ParseFloat(text, t.Bits()); err == nil {
+			return f
+		}
+	}
+
+	return text
+}
+
+// withDefaults returns a copy of the tree with the default values added
+// where the keys are absent.  Values present in the tree are never altered.
+func withDefaults(obj meta.Object, defaults map[string]any, origins []meta.Origin) meta.Object {
+	if len(defaults) == 0 {
+		return obj
+	}
+
+	// Only maps (including an empty tree) can have defaults added.
+	if obj.Map == nil && (obj.Array != nil || obj.Value != nil) {
+		return obj
+	}
+
+	m := make(map[string]meta.Object, len(obj.Map)+len(defaults))
+	for k, v := range obj.Map {
+		m[k] = v
+	}
+
+	for k, v := range defaults {
+		existing, found := m[k]
+		if !found {
+			m[k] = meta.ObjectFromRawWithOrigin(v, origins)
+			continue
+		}
+
+		if sub, ok := v.(map[string]any); ok {
+			m[k] = withDefaults(existing, sub, origins)
+		}
+	}
+
+	obj.Map = m
+	if obj.Origins == nil {
+		obj.Origins = origins
+	}
+	return obj
+}
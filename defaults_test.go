@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsEmbedded struct {
+	Region string `default:"us-east"`
+}
+
+type defaultsInner struct {
+	Retries uint8   `goschtalt:"retries" default:"3"`
+	Ratio   float32 `goschtalt:"ratio" default:"0.5"`
+	Name    string  `goschtalt:"name"`
+}
+
+type defaultsRecursive struct {
+	Depth int `default:"1"`
+	Next  *defaultsRecursive
+}
+
+type defaultsConfig struct {
+	defaultsEmbedded `goschtalt:",squash"`
+
+	Port      int               `goschtalt:"port" default:"8080"`
+	Enabled   bool              `goschtalt:"enabled" default:"true"`
+	Timeout   time.Duration     `goschtalt:"timeout" default:"30s"`
+	Mode      string            `goschtalt:"mode" default:"fast"`
+	Inner     defaultsInner     `goschtalt:"inner"`
+	Recursive defaultsRecursive `goschtalt:"recursive"`
+	Ignored   int               `goschtalt:"-" default:"9"`
+	Extra     map[string]any    `goschtalt:",remain" default:"x"`
+	hidden    int               `default:"7"` //nolint:unused
+}
+
+var defaultsDurationAdapter = AdaptFromCfg(AdapterFromCfgFunc(
+	func(from, to reflect.Value) (any, error) {
+		if from.Kind() != reflect.String || to.Type() != reflect.TypeOf(time.Duration(0)) {
+			return nil, ErrNotApplicable
+		}
+		return time.ParseDuration(from.String())
+	}), "duration")
+
+func TestTagDefaults(t *testing.T) {
+	td := tagDefaults{
+		tagName: defaultTag,
+		mapper:  strings.ToLower,
+	}
+
+	assert.Equal(t, map[string]any{
+		"region":  "us-east",
+		"port":    int64(8080),
+		"enabled": true,
+		"timeout": "30s",
+		"mode":    "fast",
+		"inner": map[string]any{
+			"retries": uint64(3),
+			"ratio":   float64(0.5),
+		},
+		"recursive": map[string]any{
+			"depth": int64(1),
+		},
+	}, td.build(reflect.TypeOf(&defaultsConfig{})))
+
+	assert.Nil(t, td.build(reflect.TypeOf(0)))
+	assert.Nil(t, td.build(reflect.TypeOf(struct{ A int }{})))
+}
+
+func TestDefaultValue(t *testing.T) {
+	tests := []struct {
+		description string
+		t           reflect.Type
+		text        string
+		expected    any
+	}{
+		{"bool", reflect.TypeOf(true), "false", false},
+		{"invalid bool", reflect.TypeOf(true), "yes", "yes"},
+		{"int8", reflect.TypeOf(int8(0)), "-0x10", int64(-16)},
+		{"int overflow", reflect.TypeOf(int8(0)), "1000", "1000"},
+		{"uint", reflect.TypeOf(uint(0)), "12", uint64(12)},
+		{"float", reflect.TypeOf(float64(0)), "1.25", float64(1.25)},
+		{"pointer", reflect.TypeOf(new(int)), "5", int64(5)},
+		{"duration", reflect.TypeOf(time.Duration(0)), "5m", "5m"},
+		{"slice", reflect.TypeOf([]string{}), "a", "a"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, defaultValue(tc.t, tc.text))
+		})
+	}
+}
+
+func TestUnmarshalDefaults(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		key         string
+		uopts       []UnmarshalOption
+		expected    defaultsConfig
+		expectedErr error
+	}{
+		{
+			description: "everything is defaulted",
+			key:         "missing",
+			uopts:       []UnmarshalOption{Optional()},
+			expected: defaultsConfig{
+				defaultsEmbedded: defaultsEmbedded{Region: "us-east"},
+				Port:             8080,
+				Enabled:          true,
+				Timeout:          30 * time.Second,
+				Mode:             "fast",
+				Inner:            defaultsInner{Retries: 3, Ratio: 0.5},
+				Recursive:        defaultsRecursive{Depth: 1},
+			},
+		}, {
+			description: "present values are not replaced",
+			opts: []Option{
+				AddValue("record", "conf", map[string]any{
+					"port":    0,
+					"enabled": false,
+					"inner": map[string]any{
+						"name":    "alice",
+						"retries": 5,
+					},
+				}),
+			},
+			key: "conf",
+			expected: defaultsConfig{
+				defaultsEmbedded: defaultsEmbedded{Region: "us-east"},
+				Timeout:          30 * time.Second,
+				Mode:             "fast",
+				Inner:            defaultsInner{Retries: 5, Ratio: 0.5, Name: "alice"},
+				Recursive:        defaultsRecursive{Depth: 1},
+			},
+		}, {
+			description: "a value that isn't a map isn't altered",
+			opts: []Option{
+				AddValue("record", "conf", map[string]any{
+					"inner": "text",
+				}),
+			},
+			key:         "conf",
+			expectedErr: ErrDecoding,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opts := append([]Option{
+				DefaultUnmarshalOptions(defaultsDurationAdapter),
+			}, tc.opts...)
+
+			cfg, err := New(opts...)
+			require.NoError(err)
+
+			got, err := Unmarshal[defaultsConfig](cfg, tc.key, tc.uopts...)
+			if tc.expectedErr != nil {
+				var ue *UnmarshalError
+				assert.ErrorAs(err, &ue)
+				return
+			}
+
+			require.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestUnmarshalDefaultsStrictness(t *testing.T) {
+	cfg, err := New(
+		AddValue("record", "inner", map[string]any{"name": "alice"}),
+	)
+	require.NoError(t, err)
+
+	got, err := Unmarshal[defaultsInner](cfg, "inner", Strictness(COMPLETE))
+	require.NoError(t, err)
+	assert.Equal(t, defaultsInner{Retries: 3, Ratio: 0.5, Name: "alice"}, got)
+}
+
+func TestAddDefaultsFromStruct(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	opt := AddDefaultsFromStruct[defaultsConfig]("conf")
+	assert.Equal("AddDefaultsFromStruct( 'goschtalt.defaultsConfig', 'conf' )", opt.String())
+
+	cfg, err := New(
+		opt,
+		AddValue("record", "conf.port", 9090),
+		DefaultUnmarshalOptions(defaultsDurationAdapter),
+	)
+	require.NoError(err)
+
+	// The defaults are part of the tree.
+	timeout, err := Unmarshal[string](cfg, "conf.timeout")
+	require.NoError(err)
+	assert.Equal("30s", timeout)
+
+	got, err := Unmarshal[defaultsConfig](cfg, "conf")
+	require.NoError(err)
+	assert.Equal(9090, got.Port)
+	assert.Equal(30*time.Second, got.Timeout)
+	assert.Equal(uint8(3), got.Inner.Retries)
+
+	// The defaults record is listed in the explanation.
+	assert.Contains(cfg.Explain().String(), "defaults: goschtalt.defaultsConfig")
+
+	// The record is a default record.
+	require.Len(cfg.opts.defaults, 1)
+	assert.Equal("defaults: goschtalt.defaultsConfig", cfg.opts.defaults[0].name)
+}
+
+func TestAddDefaultsFromStructTagName(t *testing.T) {
+	type config struct {
+		Port int `json:"listen" default:"80"`
+		None int
+	}
+
+	cfg, err := New(
+		AddDefaultsFromStruct[*config](Root, TagName("json")),
+	)
+	require.NoError(t, err)
+
+	port, err := Unmarshal[int64](cfg, "listen")
+	require.NoError(t, err)
+	assert.Equal(t, int64(80), port)
+}
+
+func TestAddDefaultsFromStructEmpty(t *testing.T) {
+	cfg, err := New(
+		AddDefaultsFromStruct[struct{ A int }](Root),
+		AddDefaultsFromStruct[any](Root),
+	)
+	require.NoError(t, err)
+
+	keys, err := Unmarshal[map[string]any](cfg, Root, Optional())
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
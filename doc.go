@@ -32,9 +32,26 @@
 //     configuration may come from anything that implements that interface.
 //   - Package defaults are set via goschtalt.DefaultOptions, but can be replaced
 //     when invoking a new goschtalt.Config object.
-//   - Default values are supported at runtime.
+//   - Default values are supported at runtime, including from struct tags.
+//   - Environment variables may be added to the configuration tree with
+//     AddEnv, including nesting, case conversion and typed values.
+//   - Command line flags may be added to the configuration tree with AddFlags
+//     or AddFlagSet, including flags that name configuration files.
+//   - Configuration may be fetched from http or https urls with AddURL,
+//     using conditional requests to avoid decoding unchanged documents.
+//   - Individual values, including array items, may be overridden with
+//     AddOverrides using keys like 'servers[1].port'.
 //   - Variable expansion in the configuration tree is supported for both
-//     environment variables as well as custom values.
+//     environment variables as well as custom values.  Scheme prefixed
+//     variables like ${file:/run/secrets/db_pass} may be marked as secrets,
+//     and shell style operators like ${PORT:-8080} are supported.
+//   - Configuration files may be watched for changes, with subscribers told
+//     which keys changed after each recompilation.
+//   - JSON Schema documents may be generated from configuration structures and
+//     used to validate the configuration tree, reporting where in the files
+//     the invalid values were found.
+//   - Struct tags may include validation rules (required, min, max, oneof,
+//     pattern, nonzero) that are enforced when unmarshaling.
 //   - No singleton objects.
 //   - Low dependency count.
 //
@@ -51,6 +68,15 @@
 //   - https://github.com/goschtalt/yaml-encoder
 //   - https://github.com/goschtalt/yaml-decoder
 //
+// Decoders and encoders that only depend upon the go standard library are
+// included in the following packages:
+//
+//   - github.com/goschtalt/goschtalt/pkg/dotenv
+//   - github.com/goschtalt/goschtalt/pkg/hcl
+//   - github.com/goschtalt/goschtalt/pkg/ini
+//   - github.com/goschtalt/goschtalt/pkg/json
+//   - github.com/goschtalt/goschtalt/pkg/toml
+//
 // # How do I decorate my configuration files to take full advantage of goschtalt?
 //
 // For most of the decoders you can specify instructions for goschtalt's handling
@@ -112,6 +138,25 @@
 // But you can only have one or two instructions (one MUST be secret if there are
 // two.
 //
+// # How do I split a configuration file into several files?
+//
+// Configuration files added as files (not buffers or values) may include other
+// files from the same fs.FS using the 'include' instruction.  The value is a
+// path or glob, or an array of them, relative to the directory of the including
+// file.  Paths starting with '/' are relative to the root of the fs.FS.
+//
+//	name: example
+//	servers((include)): conf.d/servers/*.yml
+//	((include)): conf.d/common.yml
+//
+// The included files are merged in sorted order and placed at the key, or
+// merged into the enclosing map if the key is only the instruction.  They are
+// merged just before the including file, so the other keys in the including
+// file (and their instructions like 'replace' or 'secret') are merged on top of
+// the included files.  Included files may include other files, but not in a
+// cycle or inside an array.  The origins of the values refer to the included
+// files.
+//
 // # A bit more on secrets.
 //
 // Secrets are primarily there so that if you want to output your configuration
@@ -0,0 +1,275 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/casbab"
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+const (
+	defaultEnvRecordName = "env"
+	defaultEnvDelimiter  = "__"
+	defaultEnvCase       = "two_words"
+)
+
+// AddEnv adds the environment variables that start with the prefix as a
+// configuration record.  The prefix is removed from the names of the
+// variables, the remaining names are split into keys using the delimiter and
+// the keys are converted into the configured case.
+//
+// For example, with the prefix "APP_" the environment variable APP_DB__HOST
+// is placed at the key db.host.  The values are converted into the best
+// matching type using [meta.StringToBestType] and the origin of each value
+// is the environment variable name, like env:APP_DB__HOST.
+//
+// The environment is read each time the configuration is compiled.
+//
+// A prefix with length > 0 must be specified.
+//
+// # Default
+//
+//   - The record name is "env".  See [EnvRecordName].
+//   - The delimiter is "__".  See [EnvDelimiter].
+//   - The keys are converted to "two_words" case.  See [EnvCase].
+//
+// Valid Option Types:
+//   - [EnvOption]
+func AddEnv(prefix string, opts ...EnvOption) Option {
+	return &env{
+		text:   print.P("AddEnv", print.String(prefix), print.LiteralStringers(opts)),
+		prefix: prefix,
+		opts:   opts,
+	}
+}
+
+type env struct {
+	// The text to use when String() is called.
+	text string
+
+	// The prefix of the environment variables to include.
+	prefix string
+
+	// Options that configure how the environment variables are processed.
+	opts []EnvOption
+}
+
+func (e env) apply(opts *options) error {
+	if len(e.prefix) == 0 {
+		return fmt.Errorf("%w: a prefix with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	cfg, err := e.options()
+	if err != nil {
+		return err
+	}
+
+	opts.values = append(opts.values, record{
+		name: cfg.recordName,
+		env:  &e,
+	})
+	return nil
+}
+
+func (_ env) ignoreDefaults() bool {
+	return false
+}
+
+func (e env) String() string {
+	return e.text
+}
+
+// options applies the EnvOptions to the default settings.
+func (e env) options() (envOptions, error) {
+	cfg := envOptions{
+		recordName: defaultEnvRecordName,
+		delimiter:  defaultEnvDelimiter,
+		toCase:     casbab.Find(defaultEnvCase),
+	}
+
+	for _, opt := range e.opts {
+		if err := opt.envApply(&cfg); err != nil {
+			return envOptions{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// toTree converts the matching environment variables into a meta.Object tree.
+// This will happen during the compilation stage.
+func (e *env) toTree() (meta.Object, error) {
+	cfg, err := e.options()
+	if err != nil {
+		return meta.Object{}, err
+	}
+
+	vars := make(map[string]string)
+	for _, kv := range os.Environ() {
+		name, val, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(name, e.prefix) && len(name) > len(e.prefix) {
+			vars[name] = val
+		}
+	}
+
+	if len(vars) == 0 {
+		return meta.Object{}, nil
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tree := meta.Object{
+		Origins: []meta.Origin{{File: cfg.recordName}},
+		Map:     make(map[string]meta.Object),
+	}
+
+	for _, name := range names {
+		origin := meta.Origin{File: "env:" + name}
+		keys := strings.Split(strings.TrimPrefix(name, e.prefix), cfg.delimiter)
+		for i := range keys {
+			if keys[i] == "" {
+				return meta.Object{}, fmt.Errorf("%w: environment variable '%s' has an empty key", ErrDecoding, name)
+			}
+			keys[i] = cfg.toCase(keys[i])
+		}
+
+		m := tree.Map
+		for _, key := range keys[:len(keys)-1] {
+			child, found := m[key]
+			if !found {
+				child = meta.Object{
+					Origins: []meta.Origin{origin},
+					Map:     make(map[string]meta.Object),
+				}
+				m[key] = child
+			}
+			if child.Map == nil {
+				return meta.Object{}, fmt.Errorf("%w: environment variable '%s' conflicts with '%s'",
+					ErrDecoding, name, child.Origins[0].File)
+			}
+			m = child.Map
+		}
+
+		last := keys[len(keys)-1]
+		if existing, found := m[last]; found {
+			return meta.Object{}, fmt.Errorf("%w: environment variable '%s' conflicts with '%s'",
+				ErrDecoding, name, existing.Origins[0].File)
+		}
+		m[last] = meta.Object{
+			Origins: []meta.Origin{origin},
+			Value:   meta.StringToBestType(vars[name]),
+		}
+	}
+
+	return tree, nil
+}
+
+// -- EnvOption options follow -------------------------------------------------
+
+// EnvOption provides the means to configure how environment variables are
+// added to the configuration.
+type EnvOption interface {
+	fmt.Stringer
+
+	envApply(*envOptions) error
+}
+
+type envOptions struct {
+	recordName string
+	delimiter  string
+	toCase     func(string) string
+}
+
+// EnvRecordName sets the record name used for sorting the environment
+// variables relative to the other configuration records.  A name with
+// length > 0 must be specified.
+//
+// # Default
+//
+// The default value is "env".
+func EnvRecordName(name string) EnvOption {
+	return envRecordNameOption(name)
+}
+
+type envRecordNameOption string
+
+func (e envRecordNameOption) envApply(opts *envOptions) error {
+	if len(e) == 0 {
+		return fmt.Errorf("%w: a record name with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	opts.recordName = string(e)
+	return nil
+}
+
+func (e envRecordNameOption) String() string {
+	return print.P("EnvRecordName", print.String(string(e)), print.SubOpt())
+}
+
+// EnvDelimiter sets the delimiter used to split the environment variable names
+// into keys.  A delimiter with length > 0 must be specified.
+//
+// # Default
+//
+// The default value is "__".
+func EnvDelimiter(delimiter string) EnvOption {
+	return envDelimiterOption(delimiter)
+}
+
+type envDelimiterOption string
+
+func (e envDelimiterOption) envApply(opts *envOptions) error {
+	if len(e) == 0 {
+		return fmt.Errorf("%w: a delimiter with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	opts.delimiter = string(e)
+	return nil
+}
+
+func (e envDelimiterOption) String() string {
+	return print.P("EnvDelimiter", print.String(string(e)), print.SubOpt())
+}
+
+// EnvCase sets the case the keys are converted into.  The formats are the
+// same as those accepted by [ConfigIs].  The special format "" leaves the keys
+// as they are.
+//
+// # Default
+//
+// The default value is "two_words".
+func EnvCase(format string) EnvOption {
+	return envCaseOption(format)
+}
+
+type envCaseOption string
+
+func (e envCaseOption) envApply(opts *envOptions) error {
+	if len(e) == 0 {
+		opts.toCase = func(s string) string { return s }
+		return nil
+	}
+
+	toCase := casbab.Find(string(e))
+	if toCase == nil {
+		return fmt.Errorf("%w: unknown case '%s'", ErrInvalidInput, string(e))
+	}
+
+	opts.toCase = toCase
+	return nil
+}
+
+func (e envCaseOption) String() string {
+	return print.P("EnvCase", print.String(string(e)), print.SubOpt())
+}
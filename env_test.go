@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func envAt(name string) []meta.Origin {
+	return []meta.Origin{{File: "env:" + name}}
+}
+
+func TestAddEnv(t *testing.T) {
+	tests := []struct {
+		description string
+		prefix      string
+		opts        []EnvOption
+		env         map[string]string
+		str         string
+		recordName  string
+		expected    meta.Object
+		expectedErr error
+		applyErr    bool
+	}{
+		{
+			description: "no matching variables",
+			prefix:      "GOSCHTALT_TEST_",
+			env:         map[string]string{"GOSCHTALT_TEST_": "ignored"},
+			str:         "AddEnv( 'GOSCHTALT_TEST_' )",
+			recordName:  "env",
+			expected:    meta.Object{},
+		}, {
+			description: "nested keys with the defaults",
+			prefix:      "GOSCHTALT_TEST_",
+			env: map[string]string{
+				"GOSCHTALT_TEST_DB__HOST":      "localhost",
+				"GOSCHTALT_TEST_DB__PORT":      "5432",
+				"GOSCHTALT_TEST_DB__TLS":       "true",
+				"GOSCHTALT_TEST_MAX_CONNS":     "1.5",
+				"GOSCHTALT_TEST_LOG__LEVEL__X": "debug",
+			},
+			str:        "AddEnv( 'GOSCHTALT_TEST_' )",
+			recordName: "env",
+			expected: meta.Object{
+				Origins: []meta.Origin{{File: "env"}},
+				Map: map[string]meta.Object{
+					"db": {
+						Origins: envAt("GOSCHTALT_TEST_DB__HOST"),
+						Map: map[string]meta.Object{
+							"host": {Origins: envAt("GOSCHTALT_TEST_DB__HOST"), Value: "localhost"},
+							"port": {Origins: envAt("GOSCHTALT_TEST_DB__PORT"), Value: int64(5432)},
+							"tls":  {Origins: envAt("GOSCHTALT_TEST_DB__TLS"), Value: true},
+						},
+					},
+					"max_conns": {Origins: envAt("GOSCHTALT_TEST_MAX_CONNS"), Value: 1.5},
+					"log": {
+						Origins: envAt("GOSCHTALT_TEST_LOG__LEVEL__X"),
+						Map: map[string]meta.Object{
+							"level": {
+								Origins: envAt("GOSCHTALT_TEST_LOG__LEVEL__X"),
+								Map: map[string]meta.Object{
+									"x": {Origins: envAt("GOSCHTALT_TEST_LOG__LEVEL__X"), Value: "debug"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "all the options",
+			prefix:      "GOSCHTALT_TEST_",
+			opts: []EnvOption{
+				EnvRecordName("50-env"),
+				EnvDelimiter("_"),
+				EnvCase(""),
+			},
+			env: map[string]string{
+				"GOSCHTALT_TEST_Db_Host": "localhost",
+			},
+			str:        "AddEnv( 'GOSCHTALT_TEST_', EnvRecordName('50-env'), EnvDelimiter('_'), EnvCase('') )",
+			recordName: "50-env",
+			expected: meta.Object{
+				Origins: []meta.Origin{{File: "50-env"}},
+				Map: map[string]meta.Object{
+					"Db": {
+						Origins: envAt("GOSCHTALT_TEST_Db_Host"),
+						Map: map[string]meta.Object{
+							"Host": {Origins: envAt("GOSCHTALT_TEST_Db_Host"), Value: "localhost"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a different case",
+			prefix:      "GOSCHTALT_TEST_",
+			opts:        []EnvOption{EnvCase("twoWords")},
+			env: map[string]string{
+				"GOSCHTALT_TEST_MAX_CONNS": "10",
+			},
+			str:        "AddEnv( 'GOSCHTALT_TEST_', EnvCase('twoWords') )",
+			recordName: "env",
+			expected: meta.Object{
+				Origins: []meta.Origin{{File: "env"}},
+				Map: map[string]meta.Object{
+					"maxConns": {Origins: envAt("GOSCHTALT_TEST_MAX_CONNS"), Value: int64(10)},
+				},
+			},
+		}, {
+			description: "a value and a map conflict",
+			prefix:      "GOSCHTALT_TEST_",
+			env: map[string]string{
+				"GOSCHTALT_TEST_DB":       "x",
+				"GOSCHTALT_TEST_DB__HOST": "localhost",
+			},
+			str:         "AddEnv( 'GOSCHTALT_TEST_' )",
+			recordName:  "env",
+			expectedErr: ErrDecoding,
+		}, {
+			description: "a map and a value conflict",
+			prefix:      "GOSCHTALT_TEST_",
+			env: map[string]string{
+				"GOSCHTALT_TEST_A__B": "x",
+				"GOSCHTALT_TEST_a":    "y",
+			},
+			str:         "AddEnv( 'GOSCHTALT_TEST_' )",
+			recordName:  "env",
+			expectedErr: ErrDecoding,
+		}, {
+			description: "an empty key",
+			prefix:      "GOSCHTALT_TEST_",
+			env: map[string]string{
+				"GOSCHTALT_TEST_DB____HOST": "x",
+			},
+			str:         "AddEnv( 'GOSCHTALT_TEST_' )",
+			recordName:  "env",
+			expectedErr: ErrDecoding,
+		}, {
+			description: "no prefix",
+			str:         "AddEnv( '' )",
+			expectedErr: ErrInvalidInput,
+			applyErr:    true,
+		}, {
+			description: "an empty record name",
+			prefix:      "GOSCHTALT_TEST_",
+			opts:        []EnvOption{EnvRecordName("")},
+			str:         "AddEnv( 'GOSCHTALT_TEST_', EnvRecordName('') )",
+			expectedErr: ErrInvalidInput,
+			applyErr:    true,
+		}, {
+			description: "an empty delimiter",
+			prefix:      "GOSCHTALT_TEST_",
+			opts:        []EnvOption{EnvDelimiter("")},
+			str:         "AddEnv( 'GOSCHTALT_TEST_', EnvDelimiter('') )",
+			expectedErr: ErrInvalidInput,
+			applyErr:    true,
+		}, {
+			description: "an unknown case",
+			prefix:      "GOSCHTALT_TEST_",
+			opts:        []EnvOption{EnvCase("invalid")},
+			str:         "AddEnv( 'GOSCHTALT_TEST_', EnvCase('invalid') )",
+			expectedErr: ErrInvalidInput,
+			applyErr:    true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			opt := AddEnv(tc.prefix, tc.opts...)
+			assert.Equal(tc.str, opt.String())
+			assert.False(opt.ignoreDefaults())
+
+			var opts options
+			err := opt.apply(&opts)
+			if tc.applyErr {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+			require.NoError(err)
+			require.Len(opts.values, 1)
+			assert.Equal(tc.recordName, opts.values[0].name)
+
+			err = opts.values[0].fetch(".", nil, nil, nil)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, opts.values[0].tree)
+		})
+	}
+}
+
+func TestAddEnvEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	t.Setenv("GOSCHTALT_E2E_NAME", "from env")
+	t.Setenv("GOSCHTALT_E2E_DB__PORT", "5432")
+
+	gs, err := New(
+		AddValue("10-defaults", Root, map[string]any{
+			"name": "from value",
+			"db": map[string]any{
+				"host": "localhost",
+				"port": 80,
+			},
+		}),
+		AddEnv("GOSCHTALT_E2E_", EnvRecordName("20-env")),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	type db struct {
+		Host string `goschtalt:"host"`
+		Port int    `goschtalt:"port"`
+	}
+	type config struct {
+		Name string `goschtalt:"name"`
+		DB   db     `goschtalt:"db"`
+	}
+
+	got, err := Unmarshal[config](gs, Root)
+	require.NoError(err)
+	assert.Equal(config{
+		Name: "from env",
+		DB:   db{Host: "localhost", Port: 5432},
+	}, got)
+}
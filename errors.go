@@ -6,14 +6,17 @@ package goschtalt
 import "errors"
 
 var (
-	ErrAdaptFailure  = errors.New("at least one matching adapt function failed")
-	ErrDecoding      = errors.New("decoding error")
-	ErrEncoding      = errors.New("encoding error")
-	ErrNotApplicable = errors.New("not applicable")
-	ErrNotCompiled   = errors.New("the Compile() function must be called first")
-	ErrCodecNotFound = errors.New("encoder/decoder not found")
-	ErrInvalidInput  = errors.New("input is invalid")
-	ErrFileMissing   = errors.New("required file is missing")
-	ErrUnsupported   = errors.New("feature is unsupported")
-	ErrHint          = errors.New("a hint found an issue")
+	ErrAdaptFailure    = errors.New("at least one matching adapt function failed")
+	ErrDecoding        = errors.New("decoding error")
+	ErrEncoding        = errors.New("encoding error")
+	ErrNotApplicable   = errors.New("not applicable")
+	ErrNotCompiled     = errors.New("the Compile() function must be called first")
+	ErrCodecNotFound   = errors.New("encoder/decoder not found")
+	ErrInvalidInput    = errors.New("input is invalid")
+	ErrFileMissing     = errors.New("required file is missing")
+	ErrFetchFailure    = errors.New("unable to fetch the configuration")
+	ErrUnsupported     = errors.New("feature is unsupported")
+	ErrHint            = errors.New("a hint found an issue")
+	ErrSchemaViolation = errors.New("schema violation")
+	ErrRuleViolation   = errors.New("validation rule violation")
 )
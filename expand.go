@@ -5,8 +5,12 @@ package goschtalt
 
 import (
 	"fmt"
+	"io/fs"
 	"os"
+	"sort"
+	"strings"
 
+	"github.com/goschtalt/goschtalt/internal/fspath"
 	"github.com/goschtalt/goschtalt/internal/print"
 	"github.com/goschtalt/goschtalt/pkg/meta"
 )
@@ -38,8 +42,52 @@ func (envExpander) Expand(s string) (string, bool) {
 	return os.LookupEnv(s)
 }
 
+// EnvExpander provides an Expander that expands environment variables.  It is
+// generally used with [WithScheme] to support variables like ${env:HOME}.
+func EnvExpander() Expander {
+	return envExpander{}
+}
+
+type fileExpander struct {
+	fs fs.FS
+}
+
+func (f fileExpander) Expand(s string) (string, bool) {
+	file, err := fspath.ToRel(s)
+	if err != nil {
+		return "", false
+	}
+
+	data, err := fs.ReadFile(f.fs, file)
+	if err != nil {
+		return "", false
+	}
+
+	rv := strings.TrimSuffix(string(data), "\n")
+	return strings.TrimSuffix(rv, "\r"), true
+}
+
+// FileExpander provides an Expander that expands to the contents of a file
+// with any trailing newline removed.  The fs.FS is expected to be the root of
+// the filesystem, like os.DirFS("/"), since the variables are absolute paths.
+// Relative paths are relative to the current working directory.  A file that
+// can't be read is not expanded.
+//
+// It is generally used with [WithSecretScheme] to support the secrets that
+// Docker and Kubernetes provide as files:
+//
+//	goschtalt.Expand(nil,
+//		goschtalt.WithSecretScheme("file", goschtalt.FileExpander(os.DirFS("/"))),
+//	)
+//
+// expands ${file:/run/secrets/db_pass} to the password.
+func FileExpander(fsys fs.FS) Expander {
+	return fileExpander{fs: fsys}
+}
+
 // ExpandEnv is a simple way to add automatic environment variable expansion
-// after the configuration has been compiled.
+// after the configuration has been compiled.  The same shell style operators
+// as [Expand] are supported, like ${PORT:-8080}.
 //
 // Expand() and ExpandEnv() directives are evaluated in the order specified.
 //
@@ -62,12 +110,7 @@ func ExpandEnv(opts ...ExpandOption) Option {
 
 	exp.text = print.P("ExpandEnv",
 		print.Literal("..."),
-		print.Yields(
-			print.String(exp.start, "start"),
-			print.String(exp.end, "end"),
-			print.String(exp.origin, "origin"),
-			print.Int(exp.maximum, "maximum"),
-		),
+		print.Yields(exp.yields()...),
 	)
 
 	return &exp
@@ -83,6 +126,33 @@ func ExpandEnv(opts ...ExpandOption) Option {
 // values replaces ${var} or $var in the string based on the mapping function
 // provided.
 //
+// Variables prefixed with the name of a scheme and a ':' are expanded using
+// the Expander of the scheme instead.  See [WithScheme] and [WithSecretScheme].
+// The expander may be nil if only schemes are used.
+//
+// The shell style operators are supported for variables that are not found
+// as they are:
+//
+//   - ${name:-word} expands to word if name is not found or is empty.
+//   - ${name-word} expands to word if name is not found.
+//   - ${name:?word} fails the compilation with the message word if name is
+//     not found or is empty.
+//   - ${name?word} fails the compilation with the message word if name is not
+//     found.
+//   - ${name:+word} expands to word if name is found and is not empty.
+//   - ${name+word} expands to word if name is found.
+//
+// The name of an operator starting with ':' may be any variable, including
+// scheme prefixed ones like ${env:PORT:-8080} or ${file:/run/secrets/x:?}.
+// The name of an operator without a ':' must be an identifier, optionally
+// with a scheme prefix or the key delimiter, like ${PORT-8080} or
+// ${db.port-5432}.  So ${my-var} expands to var if neither my-var nor my is
+// found, where it was left as is before the operators were supported.
+//
+// An operator is only applied when no later Expand() or ExpandEnv() with the
+// same delimiters is able to resolve the variable, so the later directive
+// provides the value instead of the operator.
+//
 // Expand() and ExpandEnv() directives are evaluated in the order specified.
 //
 // Valid Option Types:
@@ -104,12 +174,7 @@ func Expand(expander Expander, opts ...ExpandOption) Option {
 	exp.text = print.P("Expand",
 		print.Obj(expander),
 		print.Literal("..."),
-		print.Yields(
-			print.String(exp.start, "start"),
-			print.String(exp.end, "end"),
-			print.String(exp.origin, "origin"),
-			print.Int(exp.maximum, "maximum"),
-		),
+		print.Yields(exp.yields()...),
 	)
 
 	return &exp
@@ -138,13 +203,25 @@ type expand struct {
 	// The maximum expansions of a value before a recursion error is returned.
 	// Defaults to 10000 if set to less than 1.
 	maximum int
+
+	// The schemes that are used instead of the expander for variables
+	// prefixed with the scheme name and ':'.
+	schemes map[string]scheme
+}
+
+// scheme is an expander for the variables prefixed with the scheme name.
+type scheme struct {
+	expander Expander
+
+	// secret means the values provided are secrets.
+	secret bool
 }
 
 func (exp expand) apply(opts *options) error {
 	if exp.maximum < 1 {
 		exp.maximum = 10000
 	}
-	if exp.expander != nil {
+	if exp.expander != nil || len(exp.schemes) > 0 {
 		opts.expansions = append(opts.expansions, exp)
 	}
 
@@ -159,27 +236,101 @@ func (exp expand) String() string {
 	return exp.text
 }
 
+// yields are the settings to include in the String() text.
+func (exp expand) yields() []print.Option {
+	rv := []print.Option{
+		print.String(exp.start, "start"),
+		print.String(exp.end, "end"),
+		print.String(exp.origin, "origin"),
+		print.Int(exp.maximum, "maximum"),
+	}
+
+	if len(exp.schemes) > 0 {
+		names := make([]string, 0, len(exp.schemes))
+		for name := range exp.schemes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		rv = append(rv, print.Strings(names, "schemes"))
+	}
+
+	return rv
+}
+
+// lookup expands the variable using the scheme it is prefixed with, or the
+// expander if there isn't one.  The value is also reported as a secret or not.
+func (exp expand) lookup(s string) (string, bool, bool) {
+	if name, rest, found := strings.Cut(s, ":"); found {
+		if sch, ok := exp.schemes[name]; ok {
+			got, found := sch.expander.Expand(rest)
+			return got, found, sch.secret
+		}
+	}
+
+	if exp.expander == nil {
+		return "", false, false
+	}
+
+	got, found := exp.expander.Expand(s)
+	return got, found, false
+}
+
+// resolves returns true if the expansion uses the same delimiters and is able
+// to expand any of the variables.
+func (exp expand) resolves(start, end string, vars ...string) bool {
+	if exp.start != start || exp.end != end {
+		return false
+	}
+
+	for _, v := range vars {
+		if _, found, _ := exp.lookup(v); found {
+			return true
+		}
+	}
+	return false
+}
+
 // expandTree is a helper function that expands variables in the configuration
-// tree.  The maximum number of expansions is limited to the max value.
-func expandTree(in meta.Object, max int, expansions []expand) (meta.Object, bool, error) {
+// tree.  The maximum number of expansions is limited to the max value.  The
+// key delimiter may be used in the names of variables with shell style
+// operators.
+//
+// A shell style operator is not applied by an expansion if a later expansion
+// is able to resolve the variable, so the later expansion provides the value.
+// If incremental is true, the '?' operators are not applied either since the
+// variable may still be provided by a later record.
+func expandTree(in meta.Object, max int, delimiter string, expansions []expand, incremental bool) (meta.Object, bool, error) {
 	changed := true
 	for i := 0; changed && i < max; i++ {
 		changed = false
-		for _, exp := range expansions {
+		for j, exp := range expansions {
+			later := expansions[j+1:]
 			var err error
-			in, err = in.ToExpanded(
-				exp.maximum,
-				exp.origin,
-				exp.start,
-				exp.end,
-				func(s string) (string, bool) {
-					got, found := exp.expander.Expand(s)
+			in, err = in.ToExpandedWithOptions(meta.ExpandOptions{
+				Maximum:   exp.maximum,
+				Origin:    exp.origin,
+				Start:     exp.start,
+				End:       exp.end,
+				Delimiter: delimiter,
+				Expander: func(s string) (string, bool, bool) {
+					got, found, secret := exp.lookup(s)
 					if found {
 						changed = true
 					}
-					return got, found
+					return got, found, secret
+				},
+				Defer: func(key, name, op string) bool {
+					if incremental && strings.HasSuffix(op, "?") {
+						return true
+					}
+					for _, l := range later {
+						if l.resolves(exp.start, exp.end, key, name) {
+							return true
+						}
+					}
+					return false
 				},
-			)
+			})
 
 			if err != nil {
 				return meta.Object{}, false, err
@@ -241,3 +392,43 @@ func (w withMaximumOption) expandApply(exp *expand) error {
 	exp.maximum = int(w)
 	return nil
 }
+
+// WithScheme adds a scheme used to expand the variables prefixed with the name
+// of the scheme and a ':'.  For example, WithScheme("env", EnvExpander())
+// expands ${env:HOME} to the value of the HOME environment variable.  The
+// name must not be empty or contain a ':' and the expander must not be nil.
+// A later scheme with the same name replaces an earlier one.
+func WithScheme(name string, expander Expander) ExpandOption {
+	return &withSchemeOption{name: name, expander: expander}
+}
+
+// WithSecretScheme is the same as [WithScheme] except the values provided by
+// the scheme are secrets.  Any value expanded using the scheme is marked as
+// secret so it is redacted by [RedactSecrets].
+func WithSecretScheme(name string, expander Expander) ExpandOption {
+	return &withSchemeOption{name: name, expander: expander, secret: true}
+}
+
+type withSchemeOption struct {
+	name     string
+	expander Expander
+	secret   bool
+}
+
+func (w withSchemeOption) expandApply(exp *expand) error {
+	if w.name == "" || strings.Contains(w.name, ":") {
+		return fmt.Errorf("%w: the scheme name '%s' must not be empty or contain ':'", ErrInvalidInput, w.name)
+	}
+	if w.expander == nil {
+		return fmt.Errorf("%w: the scheme '%s' must have a non-nil Expander", ErrInvalidInput, w.name)
+	}
+
+	if exp.schemes == nil {
+		exp.schemes = make(map[string]scheme)
+	}
+	exp.schemes[w.name] = scheme{
+		expander: w.expander,
+		secret:   w.secret,
+	}
+	return nil
+}
@@ -6,8 +6,11 @@ package goschtalt
 import (
 	"errors"
 	"testing"
+	"testing/fstest"
 
+	"github.com/goschtalt/goschtalt/pkg/meta"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExpand(t *testing.T) {
@@ -59,6 +62,34 @@ func TestExpand(t *testing.T) {
 				expander: envExpander{},
 				maximum:  10000,
 			}},
+		}, {
+			description: "Only schemes",
+			in:          Expand(nil, WithScheme("env", EnvExpander()), WithSecretScheme("file", &expander)),
+			str:         "Expand( nil, ... ) --> start: '${', end: '}', origin: '', maximum: 0, schemes: 'env', 'file'",
+			want: []expand{{
+				start:   "${",
+				end:     "}",
+				maximum: 10000,
+				schemes: map[string]scheme{
+					"env":  {expander: envExpander{}},
+					"file": {expander: &expander, secret: true},
+				},
+			}},
+		}, {
+			description: "A scheme without a name",
+			in:          Expand(nil, WithScheme("", &expander)),
+			str:         "WithError( 'Expand() err: input is invalid: the scheme name '' must not be empty or contain ':'' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "A scheme with an invalid name",
+			in:          ExpandEnv(WithSecretScheme("a:b", &expander)),
+			str:         "WithError( 'ExpandEnv() err: input is invalid: the scheme name 'a:b' must not be empty or contain ':'' )",
+			expectErr:   ErrInvalidInput,
+		}, {
+			description: "A scheme without an expander",
+			in:          Expand(nil, WithScheme("file", nil)),
+			str:         "WithError( 'Expand() err: input is invalid: the scheme 'file' must have a non-nil Expander' )",
+			expectErr:   ErrInvalidInput,
 		}, {
 			description: "Handle an error",
 			in:          ExpandEnv(WithError(testErr)),
@@ -158,3 +189,315 @@ func Test_envExpander_Expand(t *testing.T) {
 		})
 	}
 }
+
+func TestFileExpander(t *testing.T) {
+	fs := fstest.MapFS{
+		"run/secrets/password": &fstest.MapFile{Data: []byte("secret\n")},
+		"run/secrets/windows":  &fstest.MapFile{Data: []byte("secret\r\n")},
+		"run/secrets/lines":    &fstest.MapFile{Data: []byte("a\nb\n\n")},
+		"run/secrets/empty":    &fstest.MapFile{},
+	}
+
+	tests := []struct {
+		in    string
+		want  string
+		found bool
+	}{
+		{
+			in:    "/run/secrets/password",
+			want:  "secret",
+			found: true,
+		}, {
+			in:    "/run/secrets/windows",
+			want:  "secret",
+			found: true,
+		}, {
+			in:    "/run/secrets/lines",
+			want:  "a\nb\n",
+			found: true,
+		}, {
+			in:    "/run/secrets/empty",
+			found: true,
+		}, {
+			in: "/run/secrets/missing",
+		}, {
+			in: "",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got, found := FileExpander(fs).Expand(tc.in)
+			assert.Equal(tc.want, got)
+			assert.Equal(tc.found, found)
+		})
+	}
+}
+
+func TestExpandSchemes(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	t.Setenv("DB_USER", "admin")
+
+	fs := fstest.MapFS{
+		"run/secrets/db_pass":   &fstest.MapFile{Data: []byte("hunter2\n")},
+		"run/secrets/api-token": &fstest.MapFile{Data: []byte("abc-123")},
+	}
+
+	gs, err := New(
+		WithEncoder(&testEncoder{extensions: []string{"json"}}),
+		AddValue("record", Root, map[string]any{
+			"user":     "${env:DB_USER}",
+			"password": "${file:/run/secrets/db_pass}",
+			"dsn":      "${env:DB_USER}:${file:/run/secrets/db_pass}@${host}",
+			"host":     "localhost",
+			"missing":  "${file:/run/secrets/not-found}",
+			"token":    "${file:/run/secrets/api-token}",
+			"fallback": "${file:/run/secrets/not-found:-none}",
+		}),
+		Expand(mockExpander{f: func(s string) (string, bool) {
+			if s == "host" {
+				return "example.com", true
+			}
+			return "", false
+		}},
+			WithScheme("env", EnvExpander()),
+			WithSecretScheme("file", FileExpander(fs)),
+		),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		User     string `goschtalt:"user"`
+		Password string `goschtalt:"password"`
+		DSN      string `goschtalt:"dsn"`
+		Missing  string `goschtalt:"missing"`
+		Token    string `goschtalt:"token"`
+		Fallback string `goschtalt:"fallback"`
+	}
+
+	got, err := Unmarshal[config](gs, Root)
+	require.NoError(err)
+	assert.Equal(config{
+		User:     "admin",
+		Password: "hunter2",
+		DSN:      "admin:hunter2@example.com",
+		Missing:  "${file:/run/secrets/not-found}",
+		Token:    "abc-123",
+		Fallback: "none",
+	}, got)
+
+	out, err := gs.Marshal(FormatAs("json"), RedactSecrets())
+	require.NoError(err)
+	assert.Equal(`{"dsn":"REDACTED","fallback":"none","host":"localhost","missing":"${file:/run/secrets/not-found}","password":"REDACTED","token":"REDACTED","user":"admin"}`, string(out))
+}
+
+func TestExpandOperators(t *testing.T) {
+	tests := []struct {
+		description string
+		env         map[string]string
+		value       string
+		expected    string
+		expectedErr error
+	}{
+		{
+			description: "a default",
+			value:       "${GS_TEST_HOST:-localhost}:${GS_TEST_PORT:-80}",
+			env:         map[string]string{"GS_TEST_PORT": "8080"},
+			expected:    "localhost:8080",
+		}, {
+			description: "an alternate",
+			value:       "${GS_TEST_TLS:+https}${GS_TEST_PLAIN:+http}://example.com",
+			env:         map[string]string{"GS_TEST_TLS": "true"},
+			expected:    "https://example.com",
+		}, {
+			description: "a required variable that is set",
+			value:       "${GS_TEST_HOST:?the host is required}",
+			env:         map[string]string{"GS_TEST_HOST": "example.com"},
+			expected:    "example.com",
+		}, {
+			description: "a dotted name",
+			value:       "${db.host:-localhost}:${db.port-5432}",
+			expected:    "localhost:5432",
+		}, {
+			description: "a hyphenated name that is not found",
+			value:       "${my-var}",
+			expected:    "var",
+		}, {
+			description: "a required variable that is missing",
+			value:       "${GS_TEST_HOST:?the host is required}",
+			expectedErr: meta.ErrVariableNotSet,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			gs, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddBuffer("1.json", []byte(`{"url": "`+tc.value+`"}`)),
+				ExpandEnv(),
+				AutoCompile(),
+			)
+
+			if tc.expectedErr != nil {
+				require.ErrorIs(err, tc.expectedErr)
+				// The message and the origin of the value are included.
+				assert.Contains(err.Error(), "the host is required")
+				assert.Contains(err.Error(), "1.json:2[123]")
+				return
+			}
+
+			require.NoError(err)
+			got, err := Unmarshal[string](gs, "url")
+			require.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestExpandOperatorsDeferred(t *testing.T) {
+	tests := []struct {
+		description string
+		env         map[string]string
+		value       string
+		expected    string
+	}{
+		{
+			description: "a default is not used when a later expander has the value",
+			value:       "${GS_TEST_HOST:-localhost}",
+			expected:    "example.com",
+		}, {
+			description: "a required variable provided by a later expander",
+			value:       "${GS_TEST_HOST:?the host is required}",
+			expected:    "example.com",
+		}, {
+			description: "an alternate is not used when a later expander has the value",
+			value:       "${GS_TEST_HOST:+https}",
+			expected:    "https",
+		}, {
+			description: "a hyphenated name provided by a later expander",
+			value:       "${my-var}",
+			expected:    "hyphen",
+		}, {
+			description: "the earlier expander provides the value",
+			value:       "${GS_TEST_HOST:-localhost}",
+			env:         map[string]string{"GS_TEST_HOST": "env.example.com"},
+			expected:    "env.example.com",
+		}, {
+			description: "the default is used when no expander has the value",
+			value:       "${GS_TEST_PORT:-80}",
+			expected:    "80",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			gs, err := New(
+				AddValue("record", Root, map[string]any{"url": tc.value}),
+				ExpandEnv(),
+				Expand(mockExpander{f: func(s string) (string, bool) {
+					switch s {
+					case "GS_TEST_HOST":
+						return "example.com", true
+					case "my-var":
+						return "hyphen", true
+					}
+					return "", false
+				}}),
+				AutoCompile(),
+			)
+			require.NoError(err)
+
+			got, err := Unmarshal[string](gs, "url")
+			require.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestExpandRequiredByLaterRecord(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// The variable is only available after the second record is fetched.
+	var available bool
+
+	gs, err := New(
+		AddValue("1", Root, map[string]any{"a": "${b:?b is required}"}),
+		AddBufferGetter("2.json",
+			BufferGetterFunc(func(string, Unmarshaler) ([]byte, error) {
+				available = true
+				return []byte(`{"c": "d"}`), nil
+			}),
+		),
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		Expand(mockExpander{f: func(s string) (string, bool) {
+			if s == "b" && available {
+				return "value", true
+			}
+			return "", false
+		}}),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	got, err := Unmarshal[string](gs, "a")
+	require.NoError(err)
+	assert.Equal("value", got)
+}
+
+func TestExpandIncrementalWithRequired(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	// The required variable is only available after the getter runs.
+	var available bool
+	var seen string
+
+	gs, err := New(
+		AddValue("1", Root, map[string]any{
+			"home": "${HOME_DIR}",
+			"req":  "${NEEDED:?must be set}",
+		}),
+		AddValueGetter("2", Root,
+			ValueGetterFunc(func(_ string, u Unmarshaler) (any, error) {
+				available = true
+				return nil, u("home", &seen)
+			}),
+		),
+		Expand(mockExpander{f: func(s string) (string, bool) {
+			switch {
+			case s == "HOME_DIR":
+				return "/home/me", true
+			case s == "NEEDED" && available:
+				return "value", true
+			}
+			return "", false
+		}}),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	// The getter sees the expanded values even though a required variable
+	// isn't available yet.
+	assert.Equal("/home/me", seen)
+
+	got, err := Unmarshal[string](gs, "req")
+	require.NoError(err)
+	assert.Equal("value", got)
+}
@@ -38,6 +38,11 @@ type Explanation struct {
 	// Records is the ordered list of records processed.
 	Records []ExplanationRecord
 
+	// Merges is the list of keys each record altered, sorted by key.  The
+	// map is keyed by the index of the record in Records.  Only present if
+	// [ExplainMerges] is enabled.
+	Merges map[int][]ExplanationMerge
+
 	// VariableExpansions is the ordered list of variable expansion instructions
 	// applied.
 	VariableExpansions []string
@@ -77,6 +82,26 @@ func (er ExplanationRecord) String() string {
 	return fmt.Sprintf("'%s' <%s> (%s)", er.Name, user, er.Duration)
 }
 
+// ExplanationMerge is the structure that represents how a record altered a
+// specific key.
+type ExplanationMerge struct {
+	Key     string // The key that was altered.  An empty key is the root.
+	Action  string // What happened.  One of the meta.Action constants.
+	Command string // The merge command specified in the key, if any.
+}
+
+func (em ExplanationMerge) String() string {
+	key := em.Key
+	if key == "" {
+		key = "<root>"
+	}
+
+	if em.Command == "" {
+		return fmt.Sprintf("'%s' %s", key, em.Action)
+	}
+	return fmt.Sprintf("'%s' %s ((%s))", key, em.Action, em.Command)
+}
+
 func (e *Explanation) reset() {
 	e.Options = []string{}
 	e.FileExtensions = []string{}
@@ -95,6 +120,7 @@ func (e *Explanation) extsSupported(s []string) {
 func (e *Explanation) compileReset() {
 	e.CompileStartedAt = time.Time{}
 	e.Records = []ExplanationRecord{}
+	e.Merges = nil
 	e.VariableExpansions = []string{}
 	e.CompileErrors = []error{}
 }
@@ -102,16 +128,24 @@ func (e *Explanation) compileReset() {
 func (e *Explanation) compileStartedAt(t time.Time) {
 	e.CompileStartedAt = t
 	e.Records = []ExplanationRecord{}
+	e.Merges = nil
 	e.VariableExpansions = []string{}
 	e.CompileErrors = []error{}
 }
 
-func (e *Explanation) compileRecord(name string, isDefault bool, now time.Time) {
+func (e *Explanation) compileRecord(name string, isDefault bool, now time.Time, merges []ExplanationMerge) {
 	elapsed := now.Sub(e.CompileStartedAt)
 	for _, record := range e.Records {
 		elapsed -= record.Duration
 	}
 
+	if merges != nil {
+		if e.Merges == nil {
+			e.Merges = make(map[int][]ExplanationMerge)
+		}
+		e.Merges[len(e.Records)] = merges
+	}
+
 	e.Records = append(e.Records,
 		ExplanationRecord{
 			Name:     name,
@@ -179,6 +213,9 @@ func (e Explanation) String() string {
 
 		for i, record := range e.Records {
 			fmt.Fprintf(&b, "  %d. %s\n", i+1, record.String())
+			for _, merge := range e.Merges[i] {
+				fmt.Fprintf(&b, "     - %s\n", merge.String())
+			}
 		}
 	}
 	fmt.Fprintln(&b, "")
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExplainerRecord(t *testing.T) {
@@ -43,12 +44,100 @@ func TestExplainerRecord(t *testing.T) {
 	}
 }
 
+func TestExplanationMerge(t *testing.T) {
+	tests := []struct {
+		in   ExplanationMerge
+		want string
+	}{
+		{
+			in:   ExplanationMerge{Action: "set"},
+			want: "'<root>' set",
+		}, {
+			in:   ExplanationMerge{Key: "a.b", Action: "replace"},
+			want: "'a.b' replace",
+		}, {
+			in:   ExplanationMerge{Key: "a.b", Action: "keep", Command: "keep"},
+			want: "'a.b' keep ((keep))",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.in.String())
+		})
+	}
+}
+
+func TestExplainMerges(t *testing.T) {
+	opts := []Option{
+		AddValue("1.yml", Root, map[string]any{
+			"a":    "b",
+			"list": []any{"a"},
+			"map": map[string]any{
+				"c": "d",
+			},
+		}),
+		AddValue("2.yml", Root, map[string]any{
+			"a((keep))":       "x",
+			"list((prepend))": []any{"b"},
+			"map": map[string]any{
+				"c": "y",
+				"e": "f",
+			},
+		}),
+		AddValue("3.yml", Root, map[string]any{
+			"ignored((clear))": "",
+		}),
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		cfg, err := New(append(opts, ExplainMerges())...)
+		require.NoError(err)
+
+		require.Len(cfg.Explain().Records, 3)
+		merges := cfg.Explain().Merges
+		assert.Equal([]ExplanationMerge{
+			{Key: "a", Action: "set"},
+			{Key: "list", Action: "set"},
+			{Key: "map", Action: "set"},
+		}, merges[0])
+		assert.Equal([]ExplanationMerge{
+			{Key: "a", Action: "keep", Command: "keep"},
+			{Key: "list", Action: "prepend", Command: "prepend"},
+			{Key: "map.c", Action: "replace"},
+			{Key: "map.e", Action: "set"},
+		}, merges[1])
+		assert.Equal([]ExplanationMerge{
+			{Action: "clear", Command: "clear"},
+		}, merges[2])
+
+		s := cfg.Explain().String()
+		assert.Contains(s, "     - 'list' prepend ((prepend))\n")
+		assert.Contains(s, "     - '<root>' clear ((clear))\n")
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert := assert.New(t)
+		require := require.New(t)
+
+		cfg, err := New(opts...)
+		require.NoError(err)
+
+		assert.Len(cfg.Explain().Records, 3)
+		assert.Nil(cfg.Explain().Merges)
+	})
+}
+
 func TestCompileRecord(t *testing.T) {
 	tests := []struct {
 		description string
 		in          Explanation
 		add         string
 		Default     bool
+		merges      []ExplanationMerge
 		want        Explanation
 	}{
 		{
@@ -88,6 +177,34 @@ func TestCompileRecord(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "merges are keyed by the record index",
+			in: Explanation{
+				Records: []ExplanationRecord{
+					{
+						Name:     "one",
+						Duration: 5 * time.Second,
+					},
+				},
+			},
+			add:    "two",
+			merges: []ExplanationMerge{{Key: "a", Action: "set"}},
+			want: Explanation{
+				Records: []ExplanationRecord{
+					{
+						Name:     "one",
+						Duration: 5 * time.Second,
+					},
+					{
+						Name:     "two",
+						Duration: 5 * time.Second,
+					},
+				},
+				Merges: map[int][]ExplanationMerge{
+					1: {{Key: "a", Action: "set"}},
+				},
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -99,7 +216,7 @@ func TestCompileRecord(t *testing.T) {
 
 			tc.in.CompileStartedAt = start
 			tc.want.CompileStartedAt = start
-			tc.in.compileRecord(tc.add, tc.Default, end)
+			tc.in.compileRecord(tc.add, tc.Default, end, tc.merges)
 
 			assert.Equal(tc.want, tc.in)
 		})
@@ -4,8 +4,10 @@
 package goschtalt
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/fs"
 	"path"
@@ -39,6 +41,14 @@ type filegroup struct {
 
 	// as is the decoder to use for the files described by this filegroup.
 	as string
+
+	// shadows are the filesystems with a higher precedence than this
+	// filegroup.  A file present at the same path in any of them is skipped.
+	shadows []fs.FS
+
+	// masks means empty files (including links to /dev/null) are skipped.
+	// Combined with shadows this allows a file to be masked entirely.
+	masks bool
 }
 
 // toRecords walks the filegroup and finds all the records that are present and
@@ -65,6 +75,15 @@ func (g filegroup) toRecords(delimiter string, decoders *codecRegistry[decoder.D
 // toRecord handles examining a single file and returning it as part of an array
 // of records.  This allows for returning 0 or 1 record easily.
 func (g filegroup) toRecord(file, delimiter string, decoders *codecRegistry[decoder.Decoder]) ([]record, error) {
+	return g.read(file, &includer{
+		fs:        g.fs,
+		delimiter: delimiter,
+		decoders:  decoders,
+	})
+}
+
+// read reads and decodes the file, resolving any includes using the includer.
+func (g filegroup) read(file string, inc *includer) ([]record, error) {
 	f, err := g.fs.Open(file)
 	if err != nil {
 		return nil, err
@@ -84,7 +103,7 @@ func (g filegroup) toRecord(file, delimiter string, decoders *codecRegistry[deco
 		ext = strings.TrimPrefix(g.as, ".")
 	}
 
-	dec, err := decoders.find(ext)
+	dec, err := inc.decoders.find(ext)
 	if dec == nil {
 		if g.exactFile {
 			// No failures allowed.
@@ -103,7 +122,7 @@ func (g filegroup) toRecord(file, delimiter string, decoders *codecRegistry[deco
 
 	ctx := decoder.Context{
 		Filename:  basename,
-		Delimiter: delimiter,
+		Delimiter: inc.delimiter,
 	}
 
 	var tree meta.Object
@@ -115,12 +134,101 @@ func (g filegroup) toRecord(file, delimiter string, decoders *codecRegistry[deco
 		return nil, err
 	}
 
+	tree, err = inc.resolve(tree, nil, []string{file})
+	if err != nil {
+		return nil, fmt.Errorf("processing file '%s' %w", basename, err)
+	}
+
 	return []record{{
-		name: basename,
-		tree: tree,
+		name:     basename,
+		tree:     tree,
+		includes: inc.layers,
 	}}, nil
 }
 
+// includeCache remembers the files each file directly includes along with
+// the checksum of the contents they were found in, so a file is only decoded
+// to find what it includes when its contents change.
+type includeCache map[string]includeEntry
+
+type includeEntry struct {
+	sum   [sha256.Size]byte
+	globs []string
+}
+
+// fingerprint writes the names and contents of all the files the filegroup
+// currently refers to, including the files they include, into the hash.
+// Files that are missing or unreadable are skipped so their later appearance
+// results in a different fingerprint.
+func (g filegroup) fingerprint(h hash.Hash, cache includeCache, delimiter string, decoders *codecRegistry[decoder.Decoder]) error {
+	files, err := g.enumerate()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	seen := make(map[string]bool, len(cache))
+	for i, top := 0, len(files); i < len(files); i++ {
+		file := files[i]
+		if seen[file] {
+			continue
+		}
+		seen[file] = true
+
+		data, err := fs.ReadFile(g.fs, file)
+		if err != nil {
+			if err = normalizeFileError(err); err != nil {
+				return err
+			}
+			continue
+		}
+
+		fmt.Fprintf(h, "%s:%d:", file, len(data))
+		_, _ = h.Write(data)
+
+		// The files are only decoded to find the files they include; any
+		// errors are reported when the configuration is compiled.
+		sum := sha256.Sum256(data)
+		entry, found := cache[file]
+		if !found || entry.sum != sum {
+			ext := strings.TrimPrefix(path.Ext(file), ".")
+			if g.as != "" && i < top {
+				ext = strings.TrimPrefix(g.as, ".")
+			}
+
+			entry = includeEntry{sum: sum}
+			if dec, _ := decoders.find(ext); dec != nil {
+				ctx := decoder.Context{
+					Filename:  file,
+					Delimiter: delimiter,
+				}
+
+				var tree meta.Object
+				if dec.Decode(ctx, data, &tree) == nil {
+					entry.globs = findIncludes(tree, file)
+				}
+			}
+			cache[file] = entry
+		}
+
+		for _, glob := range entry.globs {
+			matches, _ := fs.Glob(g.fs, glob)
+			files = append(files, matches...)
+		}
+	}
+
+	// Forget the files that are no longer referred to.
+	for file := range cache {
+		if !seen[file] {
+			delete(cache, file)
+		}
+	}
+
+	return nil
+}
+
 // enumerate walks the specified paths and collects the files it finds that match
 // the specified extensions.
 func (g filegroup) enumerate() ([]string, error) {
@@ -153,7 +261,50 @@ func (g filegroup) enumerate() ([]string, error) {
 	}
 	sort.Strings(files)
 
-	return files, nil
+	return g.visible(files), nil
+}
+
+// visible removes the files that are shadowed or masked.
+func (g filegroup) visible(files []string) []string {
+	if len(g.shadows) == 0 && !g.masks {
+		return files
+	}
+
+	rv := make([]string, 0, len(files))
+	for _, file := range files {
+		if !g.shadowed(file) && !g.masked(file) {
+			rv = append(rv, file)
+		}
+	}
+
+	return rv
+}
+
+// shadowed returns true if the file is present in a filesystem with a higher
+// precedence.
+func (g filegroup) shadowed(file string) bool {
+	for _, shadow := range g.shadows {
+		if _, err := fs.Stat(shadow, file); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// masked returns true if the file masks the files it shadows instead of being
+// a configuration file.
+func (g filegroup) masked(file string) bool {
+	if !g.masks {
+		return false
+	}
+
+	stat, err := fs.Stat(g.fs, file)
+	if err != nil {
+		return false
+	}
+
+	// A link to /dev/null is a character device once it is followed.
+	return stat.Size() == 0 || stat.Mode()&fs.ModeDevice != 0
 }
 
 // enumeratePath examines a specific path and collects all the appropriate files.
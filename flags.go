@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/fspath"
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// setFlag is the name of the flag that sets any key to a value.
+const setFlag = "set"
+
+// AddFlags parses the command line arguments into a configuration record.  The
+// recordName is used for sorting this record relative to the other records.
+// The args should not include the program name, so os.Args[1:] is normally
+// used.
+//
+// The supported forms of the arguments are:
+//   - --db.host=localhost sets the key db.host to the value localhost.
+//   - --db.host localhost sets the key db.host to the value localhost.
+//   - --verbose sets the key verbose to true if no value follows it.
+//   - --set servers[1].port=8080 overrides the port of the second server.
+//   - -- stops processing the remaining arguments.
+//
+// The --set flags are applied the same as [AddOverrides] after the other
+// flags are merged, so they may refer to array items using index segments.
+//
+// A single leading '-' is treated the same as '--'.  The keys are split into
+// parts using the key delimiter and the values are converted into the best
+// matching type using [meta.StringToBestType].  Arguments that are not flags
+// or flag values result in an error.  Values starting with a '-' must use the
+// --key=value form.
+//
+// Configuration files may also be specified using the [ConfigFileFlag]
+// option.
+//
+// Valid Option Types:
+//   - [FlagsOption]
+func AddFlags(recordName string, args []string, opts ...FlagsOption) Option {
+	return &flags{
+		text:       print.P("AddFlags", print.String(recordName), print.Strings(args), print.LiteralStringers(opts)),
+		recordName: recordName,
+		args:       args,
+		opts:       opts,
+	}
+}
+
+// AddFlagSet adds the flags from a parsed flag.FlagSet as a configuration
+// record.  Only the flags explicitly set on the command line become values,
+// so the defaults of the flags don't replace the values found in other
+// records.  The recordName is used for sorting this record relative to the
+// other records.
+//
+// The names of the flags are the keys and are split into parts using the key
+// delimiter.  The values are converted into the best matching type using
+// [meta.StringToBestType].
+//
+// The FlagSet must be parsed before this option is applied.
+//
+// Valid Option Types:
+//   - [FlagsOption]
+func AddFlagSet(recordName string, set *flag.FlagSet, opts ...FlagsOption) Option {
+	return &flags{
+		text:       print.P("AddFlagSet", print.String(recordName), print.Obj(set), print.LiteralStringers(opts)),
+		recordName: recordName,
+		set:        set,
+		opts:       opts,
+	}
+}
+
+type flags struct {
+	// The text to use when String() is called.
+	text string
+
+	// The record name.
+	recordName string
+
+	// The source of the flags; either the args or the set.
+	args []string
+	set  *flag.FlagSet
+
+	// Options that configure how the flags are processed.
+	opts []FlagsOption
+
+	// The key value pairs found when the option was applied.
+	pairs []keyValue
+}
+
+func (f flags) apply(opts *options) error {
+	if len(f.recordName) == 0 {
+		return fmt.Errorf("%w: a recordName with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	var cfg flagsOptions
+	for _, opt := range f.opts {
+		if err := opt.flagsApply(&cfg); err != nil {
+			return err
+		}
+	}
+
+	var sets []keyValue
+	var err error
+	if f.set != nil {
+		f.pairs, err = flagSetPairs(f.set)
+	} else {
+		f.pairs, sets, err = argPairs(f.args)
+	}
+	if err != nil {
+		return err
+	}
+
+	var files []string
+	if cfg.configFile != "" {
+		pairs := make([]keyValue, 0, len(f.pairs))
+		for _, pair := range f.pairs {
+			if pair.key == cfg.configFile {
+				files = append(files, pair.val)
+				continue
+			}
+			pairs = append(pairs, pair)
+		}
+		f.pairs = pairs
+	}
+
+	for _, file := range files {
+		grp := filegroup{
+			fs:        cfg.rel,
+			exactFile: true,
+		}
+
+		if fspath.IsLocal(file) {
+			grp.paths = []string{path.Clean(file)}
+		} else {
+			rel, err := fspath.ToRel(file)
+			if err != nil {
+				return err
+			}
+			grp.fs = cfg.abs
+			grp.paths = []string{rel}
+		}
+
+		opts.filegroups = append(opts.filegroups, grp)
+	}
+
+	rec := record{
+		name:  f.recordName,
+		flags: &f,
+	}
+	if len(sets) > 0 {
+		rec.overrides = &overrides{
+			origin: "flag",
+			pairs:  sets,
+		}
+	}
+
+	opts.values = append(opts.values, rec)
+	return nil
+}
+
+func (_ flags) ignoreDefaults() bool {
+	return false
+}
+
+func (f flags) String() string {
+	return f.text
+}
+
+// argPairs converts the command line arguments into key value pairs.  The
+// pairs from the --set flags are returned separately.
+func argPairs(args []string) (pairs, sets []keyValue, err error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			break
+		}
+
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			return nil, nil, fmt.Errorf("%w: unexpected argument '%s'", ErrInvalidInput, arg)
+		}
+
+		name, val, found := strings.Cut(strings.TrimPrefix(arg[1:], "-"), "=")
+		if name == "" {
+			return nil, nil, fmt.Errorf("%w: invalid flag '%s'", ErrInvalidInput, arg)
+		}
+
+		if !found {
+			val = "true"
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				i++
+				val = args[i]
+			} else if name == setFlag {
+				return nil, nil, fmt.Errorf("%w: flag '%s' requires a key=value", ErrInvalidInput, arg)
+			}
+		}
+
+		if name == setFlag {
+			name, val, found = strings.Cut(val, "=")
+			if name == "" || !found {
+				return nil, nil, fmt.Errorf("%w: flag '%s' requires a key=value, not '%s'",
+					ErrInvalidInput, arg, val)
+			}
+			sets = append(sets, keyValue{key: name, val: val})
+			continue
+		}
+
+		pairs = append(pairs, keyValue{key: name, val: val})
+	}
+
+	return pairs, sets, nil
+}
+
+// flagSetPairs converts the flags that were set into key value pairs.
+func flagSetPairs(set *flag.FlagSet) ([]keyValue, error) {
+	if set == nil || !set.Parsed() {
+		return nil, fmt.Errorf("%w: the FlagSet must be parsed.", ErrInvalidInput)
+	}
+
+	var pairs []keyValue
+	set.Visit(func(f *flag.Flag) {
+		pairs = append(pairs, keyValue{key: f.Name, val: f.Value.String()})
+	})
+
+	return pairs, nil
+}
+
+// toTree converts the flags into a meta.Object tree.  This will happen during
+// the compilation stage.  Later flags replace the values of earlier flags.
+func (f *flags) toTree(delimiter string) (meta.Object, error) {
+	var tree meta.Object
+
+	for _, pair := range f.pairs {
+		next := meta.ObjectFromRawWithOrigin(meta.StringToBestType(pair.val),
+			[]meta.Origin{{File: "flag:" + pair.key}},
+			strings.Split(pair.key, delimiter)...)
+
+		var err error
+		tree, err = tree.Merge(next)
+		if err != nil {
+			return meta.Object{}, fmt.Errorf("%w: flag '%s': %w", ErrInvalidInput, pair.key, err)
+		}
+	}
+
+	return tree, nil
+}
+
+// -- FlagsOption options follow -----------------------------------------------
+
+// FlagsOption provides the means to configure how the command line flags are
+// added to the configuration.
+type FlagsOption interface {
+	fmt.Stringer
+
+	flagsApply(*flagsOptions) error
+}
+
+type flagsOptions struct {
+	configFile string
+	abs        fs.FS
+	rel        fs.FS
+}
+
+// ConfigFileFlag treats the flag with the specified name as the path to a
+// configuration file to include, for example --config-file=/etc/app.yml.  The
+// flag may be repeated to include more than one file.  The files must be
+// present or compiling the configuration fails.
+//
+// The files are sorted into either the relative based filesystem or the
+// absolute path based filesystem, the same as [AddJumbled], and are sorted
+// relative to the other records based on their names.
+func ConfigFileFlag(name string, abs, rel fs.FS) FlagsOption {
+	return &configFileFlagOption{
+		name: name,
+		abs:  abs,
+		rel:  rel,
+	}
+}
+
+type configFileFlagOption struct {
+	name string
+	abs  fs.FS
+	rel  fs.FS
+}
+
+func (c configFileFlagOption) flagsApply(opts *flagsOptions) error {
+	if len(c.name) == 0 {
+		return fmt.Errorf("%w: a flag name with length > 0 must be specified.", ErrInvalidInput)
+	}
+	if c.name == setFlag {
+		return fmt.Errorf("%w: the flag name '%s' is reserved.", ErrInvalidInput, setFlag)
+	}
+	if c.abs == nil || c.rel == nil {
+		return fmt.Errorf("%w: non-nil filesystems must be specified.", ErrInvalidInput)
+	}
+
+	opts.configFile = c.name
+	opts.abs = c.abs
+	opts.rel = c.rel
+	return nil
+}
+
+func (c configFileFlagOption) String() string {
+	return print.P("ConfigFileFlag",
+		print.String(c.name),
+		print.Literal("abs"),
+		print.Literal("rel"),
+		print.SubOpt(),
+	)
+}
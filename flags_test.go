@@ -0,0 +1,276 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"flag"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func flagAt(key string) []meta.Origin {
+	return []meta.Origin{{File: "flag:" + key}}
+}
+
+func TestAddFlags(t *testing.T) {
+	tests := []struct {
+		description string
+		recordName  string
+		args        []string
+		opts        []FlagsOption
+		str         string
+		expected    meta.Object
+		files       []filegroup
+		sets        []keyValue
+		applyErr    error
+	}{
+		{
+			description: "no arguments",
+			recordName:  "flags",
+			str:         "AddFlags( 'flags', '' )",
+		}, {
+			description: "all the forms",
+			recordName:  "flags",
+			args: []string{
+				"--db.host=localhost",
+				"-db.port", "5432",
+				"--verbose",
+				"--set", "db.user=admin",
+				"--set=name=a=b",
+				"--db.host", "example.com",
+				"--last",
+				"--",
+				"ignored",
+			},
+			str: "AddFlags( 'flags', '--db.host=localhost', '-db.port', '5432', '--verbose', '--set', 'db.user=admin', '--set=name=a=b', '--db.host', 'example.com', '--last', '--', 'ignored' )",
+			expected: meta.Object{
+				Origins: flagAt("db.host"),
+				Map: map[string]meta.Object{
+					"db": {
+						Origins: flagAt("db.host"),
+						Map: map[string]meta.Object{
+							"host": {Origins: flagAt("db.host"), Value: "example.com"},
+							"port": {Origins: flagAt("db.port"), Value: int64(5432)},
+						},
+					},
+					"verbose": {Origins: flagAt("verbose"), Value: true},
+					"last":    {Origins: flagAt("last"), Value: true},
+				},
+			},
+			sets: []keyValue{
+				{key: "db.user", val: "admin"},
+				{key: "name", val: "a=b"},
+			},
+		}, {
+			description: "configuration files",
+			recordName:  "flags",
+			args: []string{
+				"--config-file", "conf/app.json",
+				"--config-file=/etc/app.json",
+				"--name=x",
+			},
+			opts: []FlagsOption{ConfigFileFlag("config-file", fstest.MapFS{}, fstest.MapFS{})},
+			str:  "AddFlags( 'flags', '--config-file', 'conf/app.json', '--config-file=/etc/app.json', '--name=x', ConfigFileFlag('config-file', abs, rel) )",
+			expected: meta.Object{
+				Origins: flagAt("name"),
+				Map: map[string]meta.Object{
+					"name": {Origins: flagAt("name"), Value: "x"},
+				},
+			},
+			files: []filegroup{
+				{fs: fstest.MapFS{}, paths: []string{"conf/app.json"}, exactFile: true},
+				{fs: fstest.MapFS{}, paths: []string{"etc/app.json"}, exactFile: true},
+			},
+		}, {
+			description: "a later map replaces a value",
+			recordName:  "flags",
+			args:        []string{"--db=1", "--db.host=x"},
+			str:         "AddFlags( 'flags', '--db=1', '--db.host=x' )",
+			expected: meta.Object{
+				Origins: flagAt("db"),
+				Map: map[string]meta.Object{
+					"db": {
+						Origins: flagAt("db.host"),
+						Map: map[string]meta.Object{
+							"host": {Origins: flagAt("db.host"), Value: "x"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "no record name",
+			str:         "AddFlags( '', '' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a positional argument",
+			recordName:  "flags",
+			args:        []string{"--a=b", "file.txt"},
+			str:         "AddFlags( 'flags', '--a=b', 'file.txt' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a lone dash",
+			recordName:  "flags",
+			args:        []string{"-"},
+			str:         "AddFlags( 'flags', '-' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "an empty flag name",
+			recordName:  "flags",
+			args:        []string{"--=x"},
+			str:         "AddFlags( 'flags', '--=x' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a set without a value",
+			recordName:  "flags",
+			args:        []string{"--set"},
+			str:         "AddFlags( 'flags', '--set' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a set without a key",
+			recordName:  "flags",
+			args:        []string{"--set", "value"},
+			str:         "AddFlags( 'flags', '--set', 'value' )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a config file flag without a name",
+			recordName:  "flags",
+			opts:        []FlagsOption{ConfigFileFlag("", fstest.MapFS{}, fstest.MapFS{})},
+			str:         "AddFlags( 'flags', '', ConfigFileFlag('', abs, rel) )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a config file flag with the reserved name",
+			recordName:  "flags",
+			opts:        []FlagsOption{ConfigFileFlag("set", fstest.MapFS{}, fstest.MapFS{})},
+			str:         "AddFlags( 'flags', '', ConfigFileFlag('set', abs, rel) )",
+			applyErr:    ErrInvalidInput,
+		}, {
+			description: "a config file flag without a filesystem",
+			recordName:  "flags",
+			opts:        []FlagsOption{ConfigFileFlag("config", nil, fstest.MapFS{})},
+			str:         "AddFlags( 'flags', '', ConfigFileFlag('config', abs, rel) )",
+			applyErr:    ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opt := AddFlags(tc.recordName, tc.args, tc.opts...)
+			assert.Equal(tc.str, opt.String())
+			assert.False(opt.ignoreDefaults())
+
+			var opts options
+			err := opt.apply(&opts)
+			if tc.applyErr != nil {
+				assert.ErrorIs(err, tc.applyErr)
+				return
+			}
+			require.NoError(err)
+			require.Len(opts.values, 1)
+			assert.Equal(tc.recordName, opts.values[0].name)
+			assert.Equal(tc.files, opts.filegroups)
+			if tc.sets == nil {
+				assert.Nil(opts.values[0].overrides)
+			} else {
+				require.NotNil(opts.values[0].overrides)
+				assert.Equal(tc.sets, opts.values[0].overrides.pairs)
+			}
+
+			err = opts.values[0].fetch(".", nil, nil, nil)
+			assert.NoError(err)
+			assert.Equal(tc.expected, opts.values[0].tree)
+		})
+	}
+}
+
+func TestAddFlagSet(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String("db.host", "localhost", "")
+	set.Int("db.port", 5432, "")
+	set.Bool("verbose", false, "")
+
+	// Not parsed yet.
+	var opts options
+	err := AddFlagSet("flags", set).apply(&opts)
+	assert.ErrorIs(err, ErrInvalidInput)
+
+	require.NoError(set.Parse([]string{"-db.port=1234", "-verbose"}))
+
+	opt := AddFlagSet("flags", set)
+	assert.Contains(opt.String(), "AddFlagSet( 'flags', ")
+
+	require.NoError(opt.apply(&opts))
+	require.Len(opts.values, 1)
+	require.NoError(opts.values[0].fetch(".", nil, nil, nil))
+
+	// The db.host flag wasn't set so it isn't present.
+	assert.Equal(meta.Object{
+		Origins: flagAt("db.port"),
+		Map: map[string]meta.Object{
+			"db": {
+				Origins: flagAt("db.port"),
+				Map: map[string]meta.Object{
+					"port": {Origins: flagAt("db.port"), Value: int64(1234)},
+				},
+			},
+			"verbose": {Origins: flagAt("verbose"), Value: true},
+		},
+	}, opts.values[0].tree)
+}
+
+func TestAddFlagsEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	rel := fstest.MapFS{
+		"conf/10-app.json": &fstest.MapFile{
+			Data: []byte(`{"name": "from file", "port": 80, "hosts": ["a", "b"]}`),
+		},
+	}
+
+	gs, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddFlags("20-flags",
+			[]string{"--config-file", "conf/10-app.json", "--port=8080", "--set", "hosts[1]=c"},
+			ConfigFileFlag("config-file", fstest.MapFS{}, rel),
+		),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		Name  string   `goschtalt:"name"`
+		Port  int      `goschtalt:"port"`
+		Hosts []string `goschtalt:"hosts"`
+	}
+
+	got, err := Unmarshal[config](gs, Root)
+	require.NoError(err)
+	assert.Equal(config{Name: "from file", Port: 8080, Hosts: []string{"a", "c"}}, got)
+
+	var names []string
+	for _, rec := range gs.Explain().Records {
+		names = append(names, rec.Name)
+	}
+	assert.Equal([]string{"10-app.json", "20-flags"}, names)
+
+	// A missing configuration file is an error.
+	_, err = New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddFlags("20-flags",
+			[]string{"--config-file", "missing.json"},
+			ConfigFileFlag("config-file", fstest.MapFS{}, rel),
+		),
+		AutoCompile(),
+	)
+	assert.Error(err)
+}
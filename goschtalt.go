@@ -39,9 +39,18 @@ type Config struct {
 	mutex      sync.Mutex
 	records    []string
 	tree       meta.Object
+	prevTree   meta.Object
 	compiledAt time.Time
 	hash       []byte
 	explain    Explanation
+	provenance []provenanceEvent
+
+	subscribers []subscription
+	nextSubID   int
+	pending     *Change
+
+	// polled is called each time Watch finishes checking for changes.
+	polled func()
 
 	rawOpts []Option
 	opts    options
@@ -71,6 +80,8 @@ func New(opts ...Option) (*Config, error) {
 //
 // See also: [AutoCompile], [Compile], [New]
 func (c *Config) With(opts ...Option) error {
+	defer c.publish()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -136,6 +147,8 @@ func (c *Config) With(opts ...Option) error {
 // Compile reads in all the files configured using the options provided,
 // and merges the configuration trees into a single map for later use.
 func (c *Config) Compile() error {
+	defer c.publish()
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
@@ -162,6 +175,7 @@ func (c *Config) compileInternal(start time.Time) error {
 
 	merged := meta.Object{Map: make(map[string]meta.Object)}
 	records := make([]string, 0, len(full))
+	var history []provenanceEvent
 
 	for i, cfg := range full {
 		// Build an incremental snapshot of the configuration at this step so
@@ -169,7 +183,7 @@ func (c *Config) compileInternal(start time.Time) error {
 		// needed.
 		incremental := merged
 
-		incremental, _, err = expandTree(incremental, c.opts.exapansionMax, c.opts.expansions)
+		incremental, _, err = expandTree(incremental, c.opts.exapansionMax, c.opts.keyDelimiter, c.opts.expansions, true)
 		if err != nil {
 			return err
 		}
@@ -182,19 +196,50 @@ func (c *Config) compileInternal(start time.Time) error {
 		if err = cfg.fetch(c.opts.keyDelimiter, unmarshalFunc, c.opts.decoders, c.opts.valueOptions); err != nil {
 			return err
 		}
-		merged, err = merged.Merge(cfg.tree)
+		isDefault := i < defaultCount
+		first := len(history)
+
+		// Collecting the events requires a copy of each altered subtree, so
+		// only collect them when something will use them.
+		var reporter func(meta.MergeEvent)
+		if c.opts.trackProvenance || c.opts.explainMerges {
+			reporter = func(e meta.MergeEvent) {
+				history = append(history, provenanceEvent{
+					record:    cfg.name,
+					isDefault: isDefault,
+					event:     e,
+				})
+			}
+		}
+		merged, err = cfg.merge(merged, c.opts.keyDelimiter, reporter)
 		if err != nil {
 			return err
 		}
 		records = append(records, cfg.name)
-		c.explain.compileRecord(cfg.name, i < defaultCount, time.Now())
+		var merges []ExplanationMerge
+		if c.opts.explainMerges {
+			merges = explainMerges(history[first:], c.opts.keyDelimiter)
+		}
+		c.explain.compileRecord(cfg.name, isDefault, time.Now(), merges)
 	}
 
 	// Expand the final tree to ensure all values are expanded.
-	merged, _, err = expandTree(merged, c.opts.exapansionMax, c.opts.expansions)
+	unexpanded := merged
+	merged, _, err = expandTree(merged, c.opts.exapansionMax, c.opts.keyDelimiter, c.opts.expansions, false)
 	if err != nil {
 		return err
 	}
+	if c.opts.trackProvenance {
+		history = append(history, expansionEvents(unexpanded, merged)...)
+	} else {
+		history = nil
+	}
+
+	for _, check := range c.opts.schemas {
+		if err = check.validate(merged, c.opts.keyDelimiter); err != nil {
+			return err
+		}
+	}
 
 	// Record the expansions in effect.
 	for _, exp := range c.opts.expansions {
@@ -206,10 +251,15 @@ func (c *Config) compileInternal(start time.Time) error {
 		return err
 	}
 
+	prevHash := c.hash
+
 	c.records = records
+	c.prevTree = c.tree
 	c.tree = merged
 	c.compiledAt = start
 	c.hash = hash
+	c.provenance = history
+	c.recordChange(prevHash)
 	return nil
 }
 
@@ -310,3 +360,15 @@ func (c *Config) GetTree() meta.Object {
 
 	return c.tree.Clone()
 }
+
+// Diff returns the differences between the configuration tree produced by the
+// prior compilation and the current configuration tree.  The origins of each
+// value are included so the source of the change can be determined.  If the
+// configuration has only been compiled once, all the values are reported as
+// added.
+func (c *Config) Diff() meta.Differences {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.prevTree.Diff(c.tree)
+}
@@ -8,6 +8,9 @@ package goschtalt
 import (
 	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 	"testing/fstest"
 
@@ -23,19 +26,38 @@ func TestStdCfgLayoutNotWin(t *testing.T) {
 	// Only make sure the other things are called.  Other tests ensure the
 	// functionality works.
 	assert.NotNil(got)
+	assert.NotNil(XDGCfgLayout("name"))
+	assert.NotNil(DropInLayout("name"))
 }
 
 func TestPopulate(t *testing.T) {
 	tests := []struct {
-		description string
-		home        string
-		homeSet     bool
+		description    string
+		home           string
+		homeSet        bool
+		configHome     string
+		configDirs     string
+		configHomeSet  bool
+		configDirCount int
 	}{
 		{
-			description: "no HOME set",
+			description:    "no HOME set",
+			configDirCount: 1,
+		}, {
+			home:           "example",
+			homeSet:        true,
+			configHomeSet:  true,
+			configDirCount: 1,
+		}, {
+			description:    "XDG directories set",
+			configHome:     "/example/config",
+			configDirs:     "/example/a:relative:/example/b",
+			configHomeSet:  true,
+			configDirCount: 2,
 		}, {
-			home:    "example",
-			homeSet: true,
+			description:    "a relative XDG_CONFIG_HOME is ignored",
+			configHome:     "relative",
+			configDirCount: 1,
 		},
 	}
 	for _, tc := range tests {
@@ -45,6 +67,8 @@ func TestPopulate(t *testing.T) {
 			var s stdLocations
 
 			t.Setenv("HOME", tc.home)
+			t.Setenv("XDG_CONFIG_HOME", tc.configHome)
+			t.Setenv("XDG_CONFIG_DIRS", tc.configDirs)
 
 			s.Populate("foo")
 
@@ -56,10 +80,316 @@ func TestPopulate(t *testing.T) {
 				assert.Nil(s.home)
 			}
 			assert.NotNil(s.etc)
+			if tc.configHomeSet {
+				assert.NotNil(s.configHome)
+			} else {
+				assert.Nil(s.configHome)
+			}
+			assert.Len(s.configDirs, tc.configDirCount)
+			assert.Len(s.dropIns, 3)
+		})
+	}
+}
+
+func TestXDGCfgLayout(t *testing.T) {
+	file := func(status string) *fstest.MapFile {
+		return &fstest.MapFile{
+			Data: []byte(`{"Status": "` + status + `"}`),
+			Mode: 0755,
+		}
+	}
+
+	none := fstest.MapFS{}
+
+	local := fstest.MapFS{
+		"1.json":       file("local - wanted"),
+		"example.json": file("local - default name wanted"),
+	}
+
+	configHome := fstest.MapFS{
+		"example.json":  file("config home - wanted"),
+		"conf.d/2.json": file("config home - tree not wanted"),
+	}
+
+	configHomeTree := fstest.MapFS{
+		"conf.d/2.json": file("config home - tree wanted"),
+	}
+
+	configDir := fstest.MapFS{
+		"example.json": file("config dir - wanted"),
+	}
+
+	configDirTree := fstest.MapFS{
+		"conf.d/2.json": file("config dir - tree wanted"),
+	}
+
+	never := fstest.MapFS{
+		"example.json": file("never wanted"),
+	}
+
+	type st struct {
+		Status string
+	}
+
+	tests := []struct {
+		description string
+		appName     string
+		files       []string
+		locations   stdLocations
+		expect      string
+		records     []string
+		expectedErr error
+	}{
+		{
+			description: "local - a file in the list",
+			appName:     "example",
+			files:       []string{"", "./1.json"},
+			locations: stdLocations{
+				root:       none,
+				local:      local,
+				configHome: configHome,
+			},
+			expect:  "local - wanted",
+			records: []string{"1.json"},
+		}, {
+			description: "local - default file",
+			appName:     "example",
+			locations: stdLocations{
+				root:       none,
+				local:      local,
+				configHome: configHome,
+			},
+			expect:  "local - default name wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "config home - a file is found before the tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:       none,
+				local:      none,
+				configHome: configHome,
+				configDirs: []fs.FS{configDir},
+			},
+			expect:  "config home - wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "config home - tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:       none,
+				local:      none,
+				configHome: configHomeTree,
+				configDirs: []fs.FS{configDir},
+			},
+			expect:  "config home - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "config dirs - the first with a file",
+			appName:     "example",
+			locations: stdLocations{
+				root:       none,
+				local:      none,
+				configHome: none,
+				configDirs: []fs.FS{none, configDir, configDirTree},
+			},
+			expect:  "config dir - wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "config dirs - the first with a tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:       none,
+				local:      none,
+				configDirs: []fs.FS{none, configDirTree, configDir},
+			},
+			expect:  "config dir - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "the home and etc directories are not used",
+			appName:     "example",
+			locations: stdLocations{
+				root:  none,
+				local: none,
+				home:  configDir,
+				etc:   configDir,
+			},
+			expect:  "never wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "an empty appName",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an invalid appName",
+			appName:     "foo/bar",
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				nonWinXDGCfgLayout(tc.appName, tc.files, tc.locations),
+				AddFile(never, "example.json"),
+				AutoCompile(),
+			)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+
+			got, err := Unmarshal[st](cfg, Root)
+			require.NoError(err)
+			assert.Equal(st{Status: tc.expect}, got)
+			assert.Equal(tc.records, cfg.records)
+		})
+	}
+}
+
+func TestDropInLayout(t *testing.T) {
+	file := func(data string) *fstest.MapFile {
+		return &fstest.MapFile{
+			Data: []byte(data),
+			Mode: 0755,
+		}
+	}
+
+	// A link to /dev/null is a character device once it is followed.
+	devNull := &fstest.MapFile{
+		Mode: fs.ModeDevice | fs.ModeCharDevice | 0666,
+	}
+
+	vendor := fstest.MapFS{
+		"10-base.json":   file(`{"a": "vendor", "b": "vendor", "c": "vendor"}`),
+		"2-first.json":   file(`{"first": "vendor"}`),
+		"20-masked.json": file(`{"masked": "vendor"}`),
+		"30-nulled.json": file(`{"nulled": "vendor"}`),
+		"40-other.txt":   file(`ignored`),
+		"sub/50.json":    file(`{"sub": "vendor"}`),
+	}
+
+	runtime := fstest.MapFS{
+		"10-base.json":   file(`{"b": "runtime"}`),
+		"30-nulled.json": devNull,
+	}
+
+	admin := fstest.MapFS{
+		"10-base.json":   file(`{"c": "admin"}`),
+		"20-masked.json": file(``),
+		"99-last.json":   file(`{"a": "admin"}`),
+	}
+
+	tests := []struct {
+		description string
+		appName     string
+		dropIns     []fs.FS
+		expect      map[string]any
+		records     []string
+		expectedErr error
+	}{
+		{
+			description: "vendor only",
+			appName:     "example",
+			dropIns:     []fs.FS{vendor, fstest.MapFS{}, fstest.MapFS{}},
+			expect: map[string]any{
+				"a":      "vendor",
+				"b":      "vendor",
+				"c":      "vendor",
+				"first":  "vendor",
+				"masked": "vendor",
+				"nulled": "vendor",
+			},
+			records: []string{"2-first.json", "10-base.json", "20-masked.json", "30-nulled.json"},
+		}, {
+			description: "shadowed and masked",
+			appName:     "example",
+			dropIns:     []fs.FS{vendor, runtime, admin},
+			expect: map[string]any{
+				"a":     "admin",
+				"c":     "admin",
+				"first": "vendor",
+			},
+			records: []string{"2-first.json", "10-base.json", "99-last.json"},
+		}, {
+			description: "runtime without admin",
+			appName:     "example",
+			dropIns:     []fs.FS{vendor, runtime},
+			expect: map[string]any{
+				"b":      "runtime",
+				"first":  "vendor",
+				"masked": "vendor",
+			},
+			records: []string{"2-first.json", "10-base.json", "20-masked.json"},
+		}, {
+			description: "missing directories",
+			appName:     "example",
+			dropIns:     []fs.FS{fstest.MapFS{}, fstest.MapFS{}, fstest.MapFS{}},
+			records:     []string{},
+		}, {
+			description: "an empty appName",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an invalid appName",
+			appName:     "foo/bar",
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				nonWinDropInLayout(tc.appName, stdLocations{dropIns: tc.dropIns}),
+				AutoCompile(),
+			)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+
+			got, err := Unmarshal[map[string]any](cfg, Root)
+			require.NoError(err)
+			assert.Equal(tc.expect, got)
+			assert.Equal(tc.records, cfg.records)
 		})
 	}
 }
 
+func TestDropInLayoutDevNull(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	vendor := t.TempDir()
+	admin := t.TempDir()
+
+	require.NoError(os.WriteFile(filepath.Join(vendor, "10-a.json"), []byte(`{"a": "vendor"}`), 0600))
+	require.NoError(os.WriteFile(filepath.Join(vendor, "20-b.json"), []byte(`{"b": "vendor"}`), 0600))
+	require.NoError(os.Symlink("/dev/null", filepath.Join(admin, "10-a.json")))
+
+	cfg, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		nonWinDropInLayout("example", stdLocations{
+			dropIns: []fs.FS{os.DirFS(vendor), os.DirFS(admin)},
+		}),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	got, err := Unmarshal[map[string]any](cfg, Root)
+	require.NoError(err)
+	assert.Equal(map[string]any{"b": "vendor"}, got)
+}
+
 func TestCompileNotWin(t *testing.T) {
 	unknownErr := fmt.Errorf("unknown err")
 	remappings := debug.Collect{}
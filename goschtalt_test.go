@@ -1237,3 +1237,36 @@ func TestSetMaxExpansions(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigDiff(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	data := map[string]any{"a": "b", "c": "d"}
+	cfg, err := New(
+		AddValueGetter("record", Root,
+			mockValueGetter{
+				f: func(string, Unmarshaler) (any, error) {
+					return data, nil
+				},
+			},
+		),
+	)
+	require.NoError(err)
+
+	d := cfg.Diff()
+	assert.Len(d.Added, 2)
+	assert.Empty(d.Removed)
+	assert.Empty(d.Changed)
+
+	data = map[string]any{"a": "x"}
+	require.NoError(cfg.Compile())
+
+	d = cfg.Diff()
+	assert.Empty(d.Added)
+	require.Len(d.Removed, 1)
+	assert.Equal([]string{"c"}, d.Removed[0].Path)
+	require.Len(d.Changed, 1)
+	assert.Equal([]string{"a"}, d.Changed[0].Path)
+	assert.Equal([]meta.Origin{{File: "record"}}, d.Changed[0].NewOrigins)
+}
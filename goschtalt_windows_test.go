@@ -19,10 +19,61 @@ func TestStdCfgLayoutWin(t *testing.T) {
 	assert.NotNil(got)
 }
 
-func TestCompileWin(t *testing.T) {
+func TestPopulateWin(t *testing.T) {
+	tests := []struct {
+		description string
+		dir         string
+		dirSet      bool
+	}{
+		{
+			description: "no directories set",
+		}, {
+			description: "all directories set",
+			dir:         "example",
+			dirSet:      true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var s stdLocations
+
+			t.Setenv("LocalAppData", tc.dir)
+			t.Setenv("AppData", tc.dir)
+			t.Setenv("ProgramData", tc.dir)
+
+			s.Populate("foo")
+
+			assert.NotNil(s.local)
+			assert.NotNil(s.root)
+			if tc.dirSet {
+				assert.NotNil(s.localAppData)
+				assert.NotNil(s.appData)
+				assert.NotNil(s.programData)
+			} else {
+				assert.Nil(s.localAppData)
+				assert.Nil(s.appData)
+				assert.Nil(s.programData)
+			}
+			assert.Nil(s.home)
+			assert.Nil(s.etc)
+		})
+	}
+}
+
+func TestXDGCfgLayoutWin(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := New(XDGCfgLayout("name"))
+
+	assert.ErrorIs(err, ErrUnsupported)
+}
+
+func TestDropInLayoutWin(t *testing.T) {
 	assert := assert.New(t)
 
-	_, err := New(stdCfgLayout("name", nil))
+	_, err := New(DropInLayout("name"))
 
-	assert.Error(err)
+	assert.ErrorIs(err, ErrUnsupported)
 }
@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/strs"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// includeCmd is the key command that includes other files at the position of
+// the key.
+const includeCmd = "((include))"
+
+// includer resolves the include directives found in the configuration files
+// of a filegroup.
+//
+// A key with the include command has a value that is either a string or an
+// array of strings.  Each string is a path or glob (using the syntax of
+// path.Match) of the files to include.  The paths are relative to the
+// directory of the including file unless they start with a '/', then they are
+// relative to the root of the fs.FS.  The files are decoded based on their
+// extensions and placed at the key in sorted order:
+//
+//	database((include)): db/*.yml
+//
+// A key of only the include command places the files at the map containing
+// the key.  The included files are not merged with the including file.
+// Instead they are layers that are merged into the configuration tree just
+// before the including file, so the key commands of the including file apply
+// to the included files the same way they apply to earlier records.
+type includer struct {
+	fs        fs.FS
+	delimiter string
+	decoders  *codecRegistry[decoder.Decoder]
+
+	// layers are the trees of the included files placed at the keys of the
+	// include directives, in the order they are merged.
+	layers []meta.Object
+}
+
+// resolve removes the include directives from the tree found at the keys and
+// adds the included files to the layers.  The includes of the entire map are
+// added before the includes placed at a key, which are added before the
+// includes found deeper in the tree.  The stack is the list of files being
+// included, used to detect cycles.
+func (inc *includer) resolve(tree meta.Object, at, stack []string) (meta.Object, error) {
+	for _, item := range tree.Array {
+		if hasInclude(item) {
+			return meta.Object{}, fmt.Errorf("%w: %s: includes are not supported inside arrays",
+				ErrInvalidInput, item.OriginString())
+		}
+	}
+
+	if len(tree.Map) == 0 {
+		return tree, nil
+	}
+
+	var keys, root, named []string
+	for key := range tree.Map {
+		switch {
+		case !strings.HasSuffix(key, includeCmd):
+			keys = append(keys, key)
+		case strings.TrimSpace(strings.TrimSuffix(key, includeCmd)) == "":
+			root = append(root, key)
+		default:
+			named = append(named, key)
+		}
+	}
+	sort.Strings(keys)
+	sort.Strings(root)
+	sort.Strings(named)
+
+	for _, key := range append(root, named...) {
+		where := at
+		if name := strings.TrimSpace(strings.TrimSuffix(key, includeCmd)); name != "" {
+			where = strs.Append(at, name)
+		}
+
+		if err := inc.include(tree.Map[key], where, stack); err != nil {
+			return meta.Object{}, err
+		}
+	}
+
+	rv := tree
+	rv.Map = make(map[string]meta.Object, len(keys))
+	for _, key := range keys {
+		val, err := inc.resolve(tree.Map[key], strs.Append(at, key), stack)
+		if err != nil {
+			return meta.Object{}, err
+		}
+
+		// A map of only includes has nothing left to merge.
+		if len(val.Map) == 0 && len(tree.Map[key].Map) > 0 {
+			continue
+		}
+		rv.Map[key] = val
+	}
+
+	return rv, nil
+}
+
+// hasInclude determines if the tree contains any include directives.
+func hasInclude(tree meta.Object) bool {
+	for _, item := range tree.Array {
+		if hasInclude(item) {
+			return true
+		}
+	}
+	for key, val := range tree.Map {
+		if strings.HasSuffix(key, includeCmd) || hasInclude(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// include reads and decodes the files described by the value of an include
+// key and adds them to the layers placed at the keys.
+func (inc *includer) include(val meta.Object, at, stack []string) error {
+	globs, err := includeGlobs(val, stack[len(stack)-1])
+	if err != nil {
+		return err
+	}
+
+	for _, glob := range globs {
+		files, err := fs.Glob(inc.fs, glob)
+		if err != nil {
+			return err
+		}
+
+		// A path without any glob characters must be present.
+		if len(files) == 0 && !strings.ContainsAny(glob, `*?[\`) {
+			return fmt.Errorf("%w: included file '%s'", ErrFileMissing, glob)
+		}
+
+		for _, file := range files {
+			if err := inc.load(file, at, stack); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// includeGlobs returns the globs described by the value of an include key
+// found in the file.  The globs are relative to the root of the fs.FS.
+func includeGlobs(val meta.Object, file string) ([]string, error) {
+	list := []meta.Object{val}
+	if len(val.Array) > 0 {
+		list = val.Array
+	}
+
+	dir := path.Dir(file)
+	globs := make([]string, 0, len(list))
+	for _, item := range list {
+		glob, ok := item.Value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s: the include must be a string or an array of strings",
+				ErrInvalidInput, item.OriginString())
+		}
+
+		if strings.HasPrefix(glob, "/") {
+			glob = path.Clean(strings.TrimPrefix(glob, "/"))
+		} else {
+			glob = path.Join(dir, glob)
+		}
+		globs = append(globs, glob)
+	}
+
+	return globs, nil
+}
+
+// findIncludes returns the globs of all the files directly included by the
+// tree of the file.  Invalid include values are ignored.
+func findIncludes(tree meta.Object, file string) []string {
+	var rv []string
+	for _, item := range tree.Array {
+		rv = append(rv, findIncludes(item, file)...)
+	}
+
+	keys := make([]string, 0, len(tree.Map))
+	for key := range tree.Map {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := tree.Map[key]
+		if !strings.HasSuffix(key, includeCmd) {
+			rv = append(rv, findIncludes(val, file)...)
+			continue
+		}
+
+		globs, err := includeGlobs(val, file)
+		if err == nil {
+			rv = append(rv, globs...)
+		}
+	}
+
+	return rv
+}
+
+// load reads and decodes an included file, then adds the files it includes
+// followed by the file itself to the layers placed at the keys.
+func (inc *includer) load(file string, at, stack []string) error {
+	for _, prev := range stack {
+		if prev == file {
+			return fmt.Errorf("%w: include cycle: %s -> %s",
+				ErrInvalidInput, strings.Join(stack, " -> "), file)
+		}
+	}
+
+	data, err := fs.ReadFile(inc.fs, file)
+	if err != nil {
+		return err
+	}
+
+	ext := strings.TrimPrefix(path.Ext(file), ".")
+	dec, err := inc.decoders.find(ext)
+	if err != nil {
+		return fmt.Errorf("%w: included file '%s'", err, file)
+	}
+
+	ctx := decoder.Context{
+		Filename:  file,
+		Delimiter: inc.delimiter,
+	}
+
+	var tree meta.Object
+	if err = dec.Decode(ctx, data, &tree); err != nil {
+		return fmt.Errorf("decoder error for extension '%s' processing included file '%s' %w %v",
+			ext, file, ErrDecoding, err) //nolint:errorlint
+	}
+
+	tree, err = inc.resolve(tree, at, append(stack[:len(stack):len(stack)], file))
+	if err != nil {
+		return err
+	}
+
+	// An empty file has nothing to add.
+	if len(tree.Map) == 0 && len(tree.Array) == 0 && tree.Value == nil {
+		return nil
+	}
+
+	for i := len(at) - 1; i >= 0; i-- {
+		tree = meta.Object{
+			Origins: tree.Origins,
+			Map:     map[string]meta.Object{at[i]: tree},
+		}
+	}
+	inc.layers = append(inc.layers, tree)
+	return nil
+}
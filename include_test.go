@@ -0,0 +1,349 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncludes(t *testing.T) {
+	tests := []struct {
+		description string
+		files       map[string]string
+		expected    any
+		origins     map[string]string
+		expectedErr error
+	}{
+		{
+			description: "no includes",
+			files: map[string]string{
+				"conf/app.json": `{"name": "app"}`,
+			},
+			expected: map[string]any{"name": "app"},
+		}, {
+			description: "an include at a key with a glob",
+			files: map[string]string{
+				"conf/app.json":      `{"name": "app", "db((include))": "db/*.json"}`,
+				"conf/db/10-a.json":  `{"host": "a", "port": 1}`,
+				"conf/db/20-b.json":  `{"host": "b"}`,
+				"conf/db/ignore.txt": `ignored`,
+			},
+			expected: map[string]any{
+				"name": "app",
+				"db": map[string]any{
+					"host": "b",
+					"port": json.Number("1"),
+				},
+			},
+			origins: map[string]string{
+				"db.host": "conf/db/20-b.json",
+				"db.port": "conf/db/10-a.json",
+				"name":    "app.json",
+			},
+		}, {
+			description: "the including file wins",
+			files: map[string]string{
+				"conf/app.json": `{"db((include))": "db.json", "db": {"host": "local"}}`,
+				"conf/db.json":  `{"host": "a", "port": 1}`,
+			},
+			expected: map[string]any{
+				"db": map[string]any{
+					"host": "local",
+					"port": json.Number("1"),
+				},
+			},
+		}, {
+			description: "an include of the entire map, nested and absolute",
+			files: map[string]string{
+				"conf/app.json":      `{"name": "app", "server": {"((include))": ["/shared/server.json", "none/*.json"]}}`,
+				"shared/server.json": `{"port": 1, "name": "server", "tls((include))": "tls.json"}`,
+				"shared/tls.json":    `{"on": true}`,
+			},
+			expected: map[string]any{
+				"name": "app",
+				"server": map[string]any{
+					"name": "server",
+					"port": json.Number("1"),
+					"tls": map[string]any{
+						"on": true,
+					},
+				},
+			},
+			origins: map[string]string{
+				"server.tls.on": "shared/tls.json",
+			},
+		}, {
+			description: "an include inside an array",
+			files: map[string]string{
+				"conf/app.json":  `{"list": [{"((include))": "item.json"}]}`,
+				"conf/item.json": `{"a": "b"}`,
+			},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "a cycle",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": "a.json"}`,
+				"conf/a.json":   `{"b((include))": "b.json"}`,
+				"conf/b.json":   `{"c((include))": "a.json"}`,
+			},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "including itself",
+			files: map[string]string{
+				"conf/app.json": `{"((include))": "app.json"}`,
+			},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "a missing file",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": "missing.json"}`,
+			},
+			expectedErr: ErrFileMissing,
+		}, {
+			description: "an invalid include value",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": 12}`,
+			},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an invalid include value in an array",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": ["a.json", 12]}`,
+			},
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an unsupported file type",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": "a.txt"}`,
+				"conf/a.txt":    `text`,
+			},
+			expectedErr: ErrCodecNotFound,
+		}, {
+			description: "an invalid included file",
+			files: map[string]string{
+				"conf/app.json": `{"a((include))": "a.json"}`,
+				"conf/a.json":   `{`,
+			},
+			expectedErr: ErrDecoding,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			fs := fstest.MapFS{}
+			for name, data := range tc.files {
+				fs[name] = &fstest.MapFile{Data: []byte(data)}
+			}
+
+			dr := newRegistry[decoder.Decoder]()
+			dr.register(&testDecoder{extensions: []string{"json"}})
+
+			grp := filegroup{
+				fs:    fs,
+				paths: []string{"conf/app.json"},
+			}
+			got, err := grp.toRecords(".", dr)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			require.Len(got, 1)
+			assert.Equal("app.json", got[0].name)
+
+			tree, err := got[0].merge(meta.Object{}, ".", nil)
+			require.NoError(err)
+			assert.Equal(tc.expected, tree.ToRaw())
+
+			for key, file := range tc.origins {
+				obj, err := tree.Fetch(strings.Split(key, "."), ".")
+				require.NoError(err)
+				require.NotEmpty(obj.Origins)
+				assert.Equal(file, obj.Origins[0].File)
+			}
+		})
+	}
+}
+
+// countingDecoder counts the number of times files are decoded.
+type countingDecoder struct {
+	testDecoder
+	count int
+}
+
+func (c *countingDecoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	c.count++
+	return c.testDecoder.Decode(ctx, b, m)
+}
+
+func TestIncludesFingerprint(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"app.json":  &fstest.MapFile{Data: []byte(`{"db((include))": "db.json", "((include))": "more/*.json"}`)},
+		"db.json":   &fstest.MapFile{Data: []byte(`{"host": "a"}`)},
+		"other.txt": &fstest.MapFile{Data: []byte(`ignored`)},
+	}
+
+	dec := countingDecoder{testDecoder: testDecoder{extensions: []string{"json"}}}
+	dr := newRegistry[decoder.Decoder]()
+	dr.register(&dec)
+
+	grp := filegroup{
+		fs:    fs,
+		paths: []string{"app.json"},
+	}
+
+	cache := make(includeCache)
+	fingerprint := func() []byte {
+		h := sha256.New()
+		require.NoError(grp.fingerprint(h, cache, ".", dr))
+		return h.Sum(nil)
+	}
+
+	before := fingerprint()
+	assert.Equal(2, dec.count)
+
+	// Nothing changed, so nothing is decoded.
+	assert.Equal(before, fingerprint())
+	assert.Equal(2, dec.count)
+
+	// An included file changed.
+	fs["db.json"] = &fstest.MapFile{Data: []byte(`{"host": "b"}`)}
+	after := fingerprint()
+	assert.NotEqual(before, after)
+	assert.Equal(3, dec.count)
+
+	// A file matching an include glob appeared.
+	fs["more/a.json"] = &fstest.MapFile{Data: []byte(`{"port": 1}`)}
+	before, after = after, fingerprint()
+	assert.NotEqual(before, after)
+	assert.Equal(4, dec.count)
+
+	// The included file now includes another file.
+	fs["more/a.json"] = &fstest.MapFile{Data: []byte(`{"port": 1, "((include))": "/other.txt"}`)}
+	before, after = after, fingerprint()
+	assert.NotEqual(before, after)
+	fs["other.txt"] = &fstest.MapFile{Data: []byte(`changed`)}
+	before, after = after, fingerprint()
+	assert.NotEqual(before, after)
+	assert.Equal(5, dec.count)
+
+	// The files no longer included are forgotten.
+	delete(fs, "more/a.json")
+	fingerprint()
+	assert.Len(cache, 2)
+}
+
+func TestIncludesEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"conf/10-app.json":   &fstest.MapFile{Data: []byte(`{"name": "app", "servers((include))": "conf.d/*.json"}`)},
+		"conf/conf.d/a.json": &fstest.MapFile{Data: []byte(`{"a": {"port": 1}}`)},
+		"conf/conf.d/b.json": &fstest.MapFile{Data: []byte(`{"b": {"port": 2}}`)},
+	}
+
+	gs, err := New(
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddDir(fs, "conf"),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	type server struct {
+		Port int `goschtalt:"port"`
+	}
+	type config struct {
+		Name    string            `goschtalt:"name"`
+		Servers map[string]server `goschtalt:"servers"`
+	}
+
+	got, err := Unmarshal[config](gs, Root)
+	require.NoError(err)
+	assert.Equal(config{
+		Name: "app",
+		Servers: map[string]server{
+			"a": {Port: 1},
+			"b": {Port: 2},
+		},
+	}, got)
+}
+
+func TestIncludesCommands(t *testing.T) {
+	tests := []struct {
+		description string
+		app         string
+		included    string
+		expected    string
+	}{
+		{
+			description: "the including file marks a value as secret",
+			app:         `{"((include))": "inc.json", "password((secret))": "hunter2"}`,
+			included:    `{"password": "included", "user": "admin"}`,
+			expected:    `{"after":"yes","list":["a"],"password":"REDACTED","user":"admin"}`,
+		}, {
+			description: "the including file replaces a list",
+			app:         `{"((include))": "inc.json", "list((replace))": ["c"]}`,
+			included:    `{"list": ["b"]}`,
+			expected:    `{"after":"yes","list":["c"]}`,
+		}, {
+			description: "the included file replaces a list",
+			app:         `{"((include))": "inc.json", "list": ["c"]}`,
+			included:    `{"list((replace))": ["b"]}`,
+			expected:    `{"after":"yes","list":["b","c"]}`,
+		}, {
+			description: "the including file clears the tree",
+			app:         `{"((include))": "inc.json", "ignored((clear))": ""}`,
+			included:    `{"user": "admin"}`,
+			expected:    `{"after":"yes"}`,
+		}, {
+			description: "the included file clears the tree",
+			app:         `{"((include))": "inc.json", "user": "admin"}`,
+			included:    `{"ignored((clear))": ""}`,
+			expected:    `{"after":"yes","user":"admin"}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			require := require.New(t)
+
+			fs := fstest.MapFS{
+				"conf/10-app.json": &fstest.MapFile{Data: []byte(tc.app)},
+				"conf/inc.json":    &fstest.MapFile{Data: []byte(tc.included)},
+			}
+
+			opts := []Option{
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				WithEncoder(&testEncoder{extensions: []string{"json"}}),
+				AddValue("00-base", Root, map[string]any{"list": []any{"a"}}),
+				AddFile(fs, "conf/10-app.json"),
+				AddValue("20-after", Root, map[string]any{"after": "yes"}),
+				AutoCompile(),
+			}
+
+			gs, err := New(opts...)
+			require.NoError(err)
+
+			out, err := gs.Marshal(FormatAs("json"), RedactSecrets())
+			require.NoError(err)
+			assert.JSONEq(t, tc.expected, string(out))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// Draft is the JSON Schema dialect produced.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// The JSON Schema type names.
+const (
+	TypeArray   = "array"
+	TypeBoolean = "boolean"
+	TypeInteger = "integer"
+	TypeNull    = "null"
+	TypeNumber  = "number"
+	TypeObject  = "object"
+	TypeString  = "string"
+)
+
+// Schema is a JSON Schema document or sub-schema.  Only the keywords goschtalt
+// understands are present; unknown keywords are ignored when decoding.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is the list of allowed types.  It is encoded as a single string if
+	// there is only one type.
+	Type Types `json:"type,omitempty"`
+
+	// Object keywords.
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+
+	// Array keywords.
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	// Number keywords.
+	Minimum *float64 `json:"minimum,omitempty"`
+	Maximum *float64 `json:"maximum,omitempty"`
+
+	// String keywords.
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+	Format    string `json:"format,omitempty"`
+
+	// Any type keywords.
+	Enum []any `json:"enum,omitempty"`
+
+	// boolean is set if the schema is the boolean form: true accepts
+	// everything, false rejects everything.
+	boolean *bool
+
+	// re is the compiled Pattern.
+	re *regexp.Regexp
+}
+
+// Bool returns the boolean schema form.  True accepts everything, false
+// rejects everything.
+func Bool(b bool) *Schema {
+	return &Schema{boolean: &b}
+}
+
+// IsBool returns the boolean value and true if the schema is the boolean form.
+func (s *Schema) IsBool() (bool, bool) {
+	if s == nil || s.boolean == nil {
+		return false, false
+	}
+	return *s.boolean, true
+}
+
+// schema is used to prevent recursion when encoding and decoding.
+type schema Schema
+
+// MarshalJSON encodes the schema, including the boolean form.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if b, ok := s.IsBool(); ok {
+		return json.Marshal(b)
+	}
+	return json.Marshal((*schema)(s))
+}
+
+// UnmarshalJSON decodes the schema, including the boolean form.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var b bool
+	if err := json.Unmarshal(data, &b); err == nil {
+		*s = Schema{boolean: &b}
+		return nil
+	}
+
+	var tmp schema
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*s = Schema(tmp)
+	return nil
+}
+
+// Types is the list of types allowed by a schema.
+type Types []string
+
+// MarshalJSON encodes a single type as a string and many types as an array.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON decodes either a single type string or an array of types.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = Types(many)
+	return nil
+}
+
+// Has returns if the type is in the list.
+func (t Types) Has(typ string) bool {
+	for _, item := range t {
+		if item == typ {
+			return true
+		}
+	}
+	return false
+}
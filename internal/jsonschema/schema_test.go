@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaJSON(t *testing.T) {
+	one := 1
+	tests := []struct {
+		description string
+		in          *Schema
+		json        string
+	}{
+		{
+			description: "true",
+			in:          Bool(true),
+			json:        `true`,
+		}, {
+			description: "false",
+			in:          Bool(false),
+			json:        `false`,
+		}, {
+			description: "empty",
+			in:          &Schema{},
+			json:        `{}`,
+		}, {
+			description: "single type",
+			in:          &Schema{Type: Types{TypeString}, MinLength: &one},
+			json:        `{"type":"string","minLength":1}`,
+		}, {
+			description: "many types",
+			in:          &Schema{Type: Types{TypeString, TypeNull}},
+			json:        `{"type":["string","null"]}`,
+		}, {
+			description: "nested",
+			in: &Schema{
+				Type: Types{TypeObject},
+				Properties: map[string]*Schema{
+					"a": {Type: Types{TypeArray}, Items: &Schema{Type: Types{TypeInteger}}},
+				},
+				AdditionalProperties: Bool(false),
+				Required:             []string{"a"},
+			},
+			json: `{"type":"object","properties":{"a":{"type":"array","items":{"type":"integer"}}},"additionalProperties":false,"required":["a"]}`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			got, err := json.Marshal(tc.in)
+			require.NoError(err)
+			assert.JSONEq(tc.json, string(got))
+
+			var back Schema
+			require.NoError(json.Unmarshal([]byte(tc.json), &back))
+			assert.Equal(*tc.in, back)
+		})
+	}
+}
+
+func TestSchemaJSONInvalid(t *testing.T) {
+	assert := assert.New(t)
+
+	var s Schema
+	assert.Error(json.Unmarshal([]byte(`"invalid"`), &s))
+	assert.Error(json.Unmarshal([]byte(`{"type": 12}`), &s))
+}
+
+func TestIsBool(t *testing.T) {
+	assert := assert.New(t)
+
+	var s *Schema
+	b, ok := s.IsBool()
+	assert.False(b)
+	assert.False(ok)
+
+	b, ok = (&Schema{}).IsBool()
+	assert.False(b)
+	assert.False(ok)
+
+	b, ok = Bool(true).IsBool()
+	assert.True(b)
+	assert.True(ok)
+}
+
+func TestTypesHas(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Types{TypeString, TypeNull}.Has(TypeNull))
+	assert.False(Types{TypeString}.Has(TypeNull))
+	assert.False(Types{}.Has(TypeNull))
+}
@@ -0,0 +1,314 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/internal/strs"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// Violation describes a single value that does not satisfy the schema.
+type Violation struct {
+	Path    []string      // The path to the value.
+	Message string        // What is wrong with the value.
+	Origins []meta.Origin // Where the value came from.
+}
+
+// Parse decodes a JSON Schema document and prepares it for validation.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile compiles all the patterns found in the schema.
+func (s *Schema) compile() error {
+	if s == nil {
+		return nil
+	}
+
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+		s.re = re
+	}
+
+	for _, child := range s.Properties {
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+	if err := s.AdditionalProperties.compile(); err != nil {
+		return err
+	}
+	return s.Items.compile()
+}
+
+// Validate checks the tree against the schema and returns the violations
+// found, sorted by path.
+//
+// Configuration formats that only support strings (like environment
+// variables) are accommodated by accepting a string value where an integer,
+// number or boolean is required if the string can be converted to that type.
+func (s *Schema) Validate(obj meta.Object) []Violation {
+	var list []Violation
+	s.validate(obj, nil, &list)
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return strings.Join(list[i].Path, "\x00") < strings.Join(list[j].Path, "\x00")
+	})
+	return list
+}
+
+func (s *Schema) validate(obj meta.Object, path []string, list *[]Violation) {
+	if s == nil {
+		return
+	}
+
+	report := func(format string, a ...any) {
+		*list = append(*list, Violation{
+			Path:    path,
+			Message: fmt.Sprintf(format, a...),
+			Origins: obj.Origins,
+		})
+	}
+
+	if b, ok := s.IsBool(); ok {
+		if !b {
+			report("no value is allowed")
+		}
+		return
+	}
+
+	typ, value := typeOf(obj)
+	if len(s.Type) > 0 {
+		var match bool
+		typ, value, match = s.Type.match(typ, value)
+		if !match {
+			report("expected %s, found %s", strings.Join(s.Type, " or "), typ)
+			return
+		}
+	}
+
+	// Enumerations are only supported for scalar values.
+	if len(s.Enum) > 0 && typ != TypeObject && typ != TypeArray && !inEnum(value, s.Enum) {
+		report("must be one of %s", enumString(s.Enum))
+	}
+
+	switch typ {
+	case TypeObject:
+		s.validateObject(obj, path, list, report)
+	case TypeArray:
+		s.validateArray(obj, path, list, report)
+	case TypeInteger, TypeNumber:
+		f, _ := toFloat(value)
+		if s.Minimum != nil && f < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+	case TypeString:
+		str := value.(string)
+		n := utf8.RuneCountInString(str)
+		if s.MinLength != nil && n < *s.MinLength {
+			report("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			report("must be at most %d characters", *s.MaxLength)
+		}
+		if s.Pattern != "" {
+			re := s.re
+			if re == nil {
+				var err error
+				if re, err = regexp.Compile(s.Pattern); err != nil {
+					report("invalid pattern '%s'", s.Pattern)
+					return
+				}
+			}
+			if !re.MatchString(str) {
+				report("must match the pattern '%s'", s.Pattern)
+			}
+		}
+	}
+}
+
+func (s *Schema) validateObject(obj meta.Object, path []string, list *[]Violation, report func(string, ...any)) {
+	missing := make([]string, 0, len(s.Required))
+	for _, key := range s.Required {
+		if _, found := obj.Map[key]; !found {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		report("missing required properties '%s'", strings.Join(missing, "', '"))
+	}
+
+	for key, val := range obj.Map {
+		child := strs.Append(path, key)
+		if prop, found := s.Properties[key]; found {
+			prop.validate(val, child, list)
+			continue
+		}
+
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if b, ok := s.AdditionalProperties.IsBool(); ok && !b {
+			*list = append(*list, Violation{
+				Path:    child,
+				Message: "is not an allowed property",
+				Origins: val.Origins,
+			})
+			continue
+		}
+		s.AdditionalProperties.validate(val, child, list)
+	}
+}
+
+func (s *Schema) validateArray(obj meta.Object, path []string, list *[]Violation, report func(string, ...any)) {
+	if s.MinItems != nil && len(obj.Array) < *s.MinItems {
+		report("must have at least %d items", *s.MinItems)
+	}
+	if s.MaxItems != nil && len(obj.Array) > *s.MaxItems {
+		report("must have at most %d items", *s.MaxItems)
+	}
+	for i, val := range obj.Array {
+		s.Items.validate(val, strs.Append(path, strconv.Itoa(i)), list)
+	}
+}
+
+// typeOf determines the JSON Schema type of the object and the value.
+func typeOf(obj meta.Object) (string, any) {
+	switch obj.Kind() {
+	case meta.Array:
+		return TypeArray, nil
+	case meta.Map:
+		return TypeObject, nil
+	}
+
+	// An empty map is reported as a value.
+	if obj.Map != nil && obj.Value == nil {
+		return TypeObject, nil
+	}
+
+	switch v := obj.Value.(type) {
+	case nil:
+		return TypeNull, nil
+	case bool:
+		return TypeBoolean, v
+	case string:
+		return TypeString, v
+	}
+
+	rv := reflect.ValueOf(obj.Value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return TypeInteger, obj.Value
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			return TypeInteger, obj.Value
+		}
+		return TypeNumber, obj.Value
+	}
+
+	return fmt.Sprintf("%T", obj.Value), obj.Value
+}
+
+// match determines if the type is allowed, converting strings to the allowed
+// type if needed.  The resulting type and value are returned.
+func (t Types) match(typ string, value any) (string, any, bool) {
+	if t.Has(typ) || (typ == TypeInteger && t.Has(TypeNumber)) {
+		return typ, value, true
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		return typ, value, false
+	}
+
+	switch best := meta.StringToBestType(s).(type) {
+	case bool:
+		if t.Has(TypeBoolean) {
+			return TypeBoolean, best, true
+		}
+	case int, int64, uint64:
+		if t.Has(TypeInteger) || t.Has(TypeNumber) {
+			return TypeInteger, best, true
+		}
+	case float64:
+		if t.Has(TypeNumber) {
+			return TypeNumber, best, true
+		}
+		if t.Has(TypeInteger) && best == math.Trunc(best) {
+			return TypeInteger, best, true
+		}
+	}
+
+	return typ, value, false
+}
+
+// inEnum determines if the value is one of the enumerated values.  Numbers
+// are compared by value regardless of their type.
+func inEnum(value any, enum []any) bool {
+	for _, item := range enum {
+		if reflect.DeepEqual(value, item) {
+			return true
+		}
+
+		a, aOK := toFloat(value)
+		b, bOK := toFloat(item)
+		if aOK && bOK && a == b {
+			return true
+		}
+
+		// Strings from string only formats may hold the enumerated value.
+		if s, ok := value.(string); ok && s == fmt.Sprint(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func enumString(enum []any) string {
+	list := make([]string, len(enum))
+	for i, item := range enum {
+		list[i] = fmt.Sprintf("'%v'", item)
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+// toFloat converts a number to a float64 if possible.
+func toFloat(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
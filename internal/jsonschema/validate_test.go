@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		expectErr   bool
+	}{
+		{
+			description: "empty schema",
+			in:          `{}`,
+		}, {
+			description: "nested patterns",
+			in: `{
+				"properties": {"a": {"pattern": "^a"}},
+				"additionalProperties": {"pattern": "^b"},
+				"items": {"pattern": "^c"}
+			}`,
+		}, {
+			description: "invalid json",
+			in:          `{`,
+			expectErr:   true,
+		}, {
+			description: "invalid pattern",
+			in:          `{"pattern": "("}`,
+			expectErr:   true,
+		}, {
+			description: "invalid nested pattern",
+			in:          `{"properties": {"a": {"items": {"pattern": "("}}}}`,
+			expectErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Parse([]byte(tc.in))
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Nil(t, got)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	origin := []meta.Origin{{File: "file", Line: 1, Col: 2}}
+	value := func(v any) meta.Object {
+		return meta.Object{Origins: origin, Value: v}
+	}
+	object := func(m map[string]meta.Object) meta.Object {
+		return meta.Object{Origins: origin, Map: m}
+	}
+	array := func(a ...meta.Object) meta.Object {
+		return meta.Object{Origins: origin, Array: a}
+	}
+
+	tests := []struct {
+		description string
+		schema      string
+		in          meta.Object
+		expected    []Violation
+	}{
+		{
+			description: "empty schema allows anything",
+			schema:      `{}`,
+			in:          value("anything"),
+		}, {
+			description: "true schema allows anything",
+			schema:      `true`,
+			in:          value("anything"),
+		}, {
+			description: "false schema allows nothing",
+			schema:      `false`,
+			in:          value("anything"),
+			expected: []Violation{
+				{Message: "no value is allowed", Origins: origin},
+			},
+		}, {
+			description: "type matches",
+			schema:      `{"type": ["string", "null"]}`,
+			in:          value(nil),
+		}, {
+			description: "type mismatch",
+			schema:      `{"type": "boolean"}`,
+			in:          value(12),
+			expected: []Violation{
+				{Message: "expected boolean, found integer", Origins: origin},
+			},
+		}, {
+			description: "an integer is a number",
+			schema:      `{"type": "number"}`,
+			in:          value(12),
+		}, {
+			description: "a whole float is an integer",
+			schema:      `{"type": "integer"}`,
+			in:          value(12.0),
+		}, {
+			description: "a fractional float is not an integer",
+			schema:      `{"type": "integer"}`,
+			in:          value(12.5),
+			expected: []Violation{
+				{Message: "expected integer, found number", Origins: origin},
+			},
+		}, {
+			description: "strings are converted to integers",
+			schema:      `{"type": "integer", "minimum": 10}`,
+			in:          value("9"),
+			expected: []Violation{
+				{Message: "must be at least 10", Origins: origin},
+			},
+		}, {
+			description: "strings are converted to numbers",
+			schema:      `{"type": "number", "maximum": 1}`,
+			in:          value("1.5"),
+			expected: []Violation{
+				{Message: "must be at most 1", Origins: origin},
+			},
+		}, {
+			description: "strings are converted to booleans",
+			schema:      `{"type": "boolean"}`,
+			in:          value("true"),
+		}, {
+			description: "strings that aren't numbers",
+			schema:      `{"type": "integer"}`,
+			in:          value("twelve"),
+			expected: []Violation{
+				{Message: "expected integer, found string", Origins: origin},
+			},
+		}, {
+			description: "an empty map is an object",
+			schema:      `{"type": "object"}`,
+			in:          object(map[string]meta.Object{}),
+		}, {
+			description: "enum match",
+			schema:      `{"enum": ["a", 2]}`,
+			in:          value(uint8(2)),
+		}, {
+			description: "enum match from a string",
+			schema:      `{"enum": ["a", 2]}`,
+			in:          value("2"),
+		}, {
+			description: "enum mismatch",
+			schema:      `{"enum": ["a", 2]}`,
+			in:          value("b"),
+			expected: []Violation{
+				{Message: "must be one of ['a', '2']", Origins: origin},
+			},
+		}, {
+			description: "string lengths and pattern",
+			schema:      `{"type": "string", "minLength": 4, "pattern": "^a"}`,
+			in:          value("bcd"),
+			expected: []Violation{
+				{Message: "must be at least 4 characters", Origins: origin},
+				{Message: "must match the pattern '^a'", Origins: origin},
+			},
+		}, {
+			description: "string max length",
+			schema:      `{"maxLength": 2}`,
+			in:          value("abc"),
+			expected: []Violation{
+				{Message: "must be at most 2 characters", Origins: origin},
+			},
+		}, {
+			description: "objects",
+			schema: `{
+				"type": "object",
+				"required": ["a", "b", "c"],
+				"properties": {
+					"a": {"type": "string"}
+				},
+				"additionalProperties": {"type": "integer"}
+			}`,
+			in: object(map[string]meta.Object{
+				"a": value(1),
+				"d": value(true),
+				"e": value(3),
+			}),
+			expected: []Violation{
+				{Message: "missing required properties 'b', 'c'", Origins: origin},
+				{Path: []string{"a"}, Message: "expected string, found integer", Origins: origin},
+				{Path: []string{"d"}, Message: "expected integer, found boolean", Origins: origin},
+			},
+		}, {
+			description: "no additional properties",
+			schema:      `{"properties": {"a": {}}, "additionalProperties": false}`,
+			in: object(map[string]meta.Object{
+				"a": value(1),
+				"b": value(2),
+			}),
+			expected: []Violation{
+				{Path: []string{"b"}, Message: "is not an allowed property", Origins: origin},
+			},
+		}, {
+			description: "arrays",
+			schema:      `{"type": "array", "minItems": 3, "maxItems": 1, "items": {"type": "string"}}`,
+			in:          array(value("a"), value(2)),
+			expected: []Violation{
+				{Message: "must have at least 3 items", Origins: origin},
+				{Message: "must have at most 1 items", Origins: origin},
+				{Path: []string{"1"}, Message: "expected string, found integer", Origins: origin},
+			},
+		}, {
+			description: "nested paths",
+			schema:      `{"properties": {"a": {"items": {"properties": {"b": {"type": "null"}}}}}}`,
+			in: object(map[string]meta.Object{
+				"a": array(object(map[string]meta.Object{
+					"b": value("x"),
+				})),
+			}),
+			expected: []Violation{
+				{Path: []string{"a", "0", "b"}, Message: "expected null, found string", Origins: origin},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			s, err := Parse([]byte(tc.schema))
+			require.NoError(t, err)
+
+			got := s.Validate(tc.in)
+			if len(tc.expected) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestValidateNil(t *testing.T) {
+	var s *Schema
+	assert.Empty(t, s.Validate(meta.Object{Value: 1}))
+}
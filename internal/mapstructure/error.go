@@ -8,8 +8,38 @@ package mapstructure
 
 import (
 	"errors"
+	"reflect"
 )
 
 var (
 	ErrDecoding = errors.New("error decoding")
 )
+
+// FieldError describes a single value that could not be decoded.  It wraps
+// both ErrDecoding and the underlying error.
+type FieldError struct {
+	// Path is the list of keys in the input data that lead to the value.
+	// Slice and array indexes are included as their decimal string form.
+	// If Missing is true, the last element is the name of the field.
+	Path []string
+
+	// Expected is the type the value was being decoded into, if known.
+	Expected reflect.Type
+
+	// Value is the value that could not be decoded, if present.
+	Value any
+
+	// Missing is true if the value was not present in the input data.
+	Missing bool
+
+	// Err describes the problem.
+	Err error
+}
+
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrDecoding, e.Err}
+}
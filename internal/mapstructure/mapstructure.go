@@ -290,6 +290,9 @@ type DecoderConfig struct {
 // up the most basic Decoder.
 type Decoder struct {
 	config *DecoderConfig
+
+	// keys is the path of keys in the input data to the value being decoded.
+	keys []string
 }
 
 // Metadata contains information about decoding a structure that
@@ -466,11 +469,13 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 	}
 
 	if d.config.DecodeHook != nil {
-		// We have a DecodeHook, so let's pre-process the input.
+		// We have a DecodeHook, so let's pre-process the input.  Keep the
+		// original input for the error since a failed hook may not return it.
+		original := input
 		var err error
 		input, err = DecodeHookExec(d.config.DecodeHook, inputVal, outVal)
 		if err != nil {
-			return errors.Join(ErrDecoding, fmt.Errorf("'%s': %w", name, err))
+			return d.fieldError(outVal.Type(), original, fmt.Errorf("'%s': %w", name, err))
 		}
 	}
 
@@ -504,9 +509,7 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 		err = d.decodeFunc(name, input, outVal)
 	default:
 		// If we reached this point then we weren't able to decode it
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf("%s: unsupported type: %s", name, outputKind))
+		return d.fieldError(outVal.Type(), input, fmt.Errorf("%s: unsupported type: %s", name, outputKind))
 	}
 
 	// If we reached here, then we successfully decoded SOMETHING, so
@@ -518,6 +521,33 @@ func (d *Decoder) decode(name string, input interface{}, outVal reflect.Value) e
 	return err
 }
 
+// decodeKey decodes the input found at the key in the input data, tracking the
+// key so errors include the full path to the value.
+func (d *Decoder) decodeKey(key, name string, input interface{}, outVal reflect.Value) error {
+	d.keys = append(d.keys, key)
+	defer func() {
+		d.keys = d.keys[:len(d.keys)-1]
+	}()
+
+	return d.decode(name, input, outVal)
+}
+
+// path returns a copy of the path of keys to the value being decoded.
+func (d *Decoder) path() []string {
+	return append([]string{}, d.keys...)
+}
+
+// fieldError describes the value at the current path that could not be
+// decoded.
+func (d *Decoder) fieldError(expected reflect.Type, value interface{}, err error) error {
+	return &FieldError{
+		Path:     d.path(),
+		Expected: expected,
+		Value:    value,
+		Err:      err,
+	}
+}
+
 // This decodes a basic type (bool, int, string, etc.) and sets the
 // value to "data" of that type.
 func (d *Decoder) decodeBasic(name string, data interface{}, val reflect.Value) error {
@@ -567,9 +597,7 @@ func (d *Decoder) decodeBasic(name string, data interface{}, val reflect.Value)
 
 	dataValType := dataVal.Type()
 	if !dataValType.AssignableTo(val.Type()) {
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf("'%s' expected type '%s', got '%s'", name, val.Type(), dataValType))
+		return d.fieldError(val.Type(), data, fmt.Errorf("'%s' expected type '%s', got '%s'", name, val.Type(), dataValType))
 	}
 
 	val.Set(dataVal)
@@ -620,11 +648,9 @@ func (d *Decoder) decodeString(name string, data interface{}, val reflect.Value)
 	}
 
 	if !converted {
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf("'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 
 	return nil
@@ -640,10 +666,7 @@ func (d *Decoder) decodeInt(name string, data interface{}, val reflect.Value) er
 		val.SetInt(dataVal.Int())
 	case dataKind == reflect.Uint:
 		if math.MaxInt64 < dataVal.Uint() {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s', %d overflows int", name, dataVal.Uint()),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s', %d overflows int", name, dataVal.Uint()))
 		}
 		val.SetInt(int64(dataVal.Uint())) // nolint:gosec
 	case dataKind == reflect.Float32:
@@ -664,28 +687,19 @@ func (d *Decoder) decodeInt(name string, data interface{}, val reflect.Value) er
 		if err == nil {
 			val.SetInt(i)
 		} else {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s' as int: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s' as int: %w", name, err))
 		}
 	case isJSONNumber(dataType):
 		jn := data.(json.Number)
 		i, err := jn.Int64()
 		if err != nil {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("error decoding json.Number into %s: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("error decoding json.Number into %s: %w", name, err))
 		}
 		val.SetInt(i)
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 
 	return nil
@@ -700,10 +714,7 @@ func (d *Decoder) decodeUint(name string, data interface{}, val reflect.Value) e
 	case dataKind == reflect.Int:
 		i := dataVal.Int()
 		if i < 0 && !d.config.WeaklyTypedInput {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s', %d overflows uint", name, i),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s', %d overflows uint", name, i))
 		}
 		val.SetUint(uint64(i))
 	case dataKind == reflect.Uint:
@@ -711,10 +722,7 @@ func (d *Decoder) decodeUint(name string, data interface{}, val reflect.Value) e
 	case dataKind == reflect.Float32:
 		f := dataVal.Float()
 		if f < 0 && !d.config.WeaklyTypedInput {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s', %f overflows uint", name, f),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s', %f overflows uint", name, f))
 		}
 		val.SetUint(uint64(f))
 	case dataKind == reflect.Bool && d.config.WeaklyTypedInput:
@@ -733,28 +741,19 @@ func (d *Decoder) decodeUint(name string, data interface{}, val reflect.Value) e
 		if err == nil {
 			val.SetUint(i)
 		} else {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s' as uint: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s' as uint: %w", name, err))
 		}
 	case isJSONNumber(dataType):
 		jn := data.(json.Number)
 		i, err := strconv.ParseUint(string(jn), 0, 64)
 		if err != nil {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("error decoding json.Number into %s: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("error decoding json.Number into %s: %w", name, err))
 		}
 		val.SetUint(i)
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 
 	return nil
@@ -780,18 +779,12 @@ func (d *Decoder) decodeBool(name string, data interface{}, val reflect.Value) e
 		} else if dataVal.String() == "" {
 			val.SetBool(false)
 		} else {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s' as bool: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s' as bool: %w", name, err))
 		}
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 
 	return nil
@@ -825,28 +818,19 @@ func (d *Decoder) decodeFloat(name string, data interface{}, val reflect.Value)
 		if err == nil {
 			val.SetFloat(f)
 		} else {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("cannot parse '%s' as float: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("cannot parse '%s' as float: %w", name, err))
 		}
 	case isJSONNumber(dataType):
 		jn := data.(json.Number)
 		i, err := jn.Float64()
 		if err != nil {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf("error decoding json.Number into %s: %w", name, err),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf("error decoding json.Number into %s: %w", name, err))
 		}
 		val.SetFloat(i)
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 
 	return nil
@@ -884,10 +868,7 @@ func (d *Decoder) decodeMap(name string, data interface{}, val reflect.Value) er
 		fallthrough
 
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf("'%s' expected a map, got '%s'", name, dataVal.Kind()),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf("'%s' expected a map, got '%s'", name, dataVal.Kind()))
 	}
 }
 
@@ -899,7 +880,7 @@ func (d *Decoder) decodeMapFromSlice(name string, dataVal reflect.Value, val ref
 	}
 
 	for i := 0; i < dataVal.Len(); i++ {
-		err := d.decode(
+		err := d.decodeKey(strconv.Itoa(i),
 			name+"["+strconv.Itoa(i)+"]",
 			dataVal.Index(i).Interface(), val)
 		if err != nil {
@@ -937,7 +918,7 @@ func (d *Decoder) decodeMapFromMap(name string, dataVal reflect.Value, val refle
 
 		// First decode the key into the proper type
 		currentKey := reflect.Indirect(reflect.New(valKeyType))
-		if err := d.decode(fieldName, k.Interface(), currentKey); err != nil {
+		if err := d.decodeKey(fmt.Sprint(k.Interface()), fieldName, k.Interface(), currentKey); err != nil {
 			errs = append(errs, err)
 			continue
 		}
@@ -945,7 +926,7 @@ func (d *Decoder) decodeMapFromMap(name string, dataVal reflect.Value, val refle
 		// Next decode the data into the proper type
 		v := dataVal.MapIndex(k).Interface()
 		currentVal := reflect.Indirect(reflect.New(valElemType))
-		if err := d.decode(fieldName, v, currentVal); err != nil {
+		if err := d.decodeKey(fmt.Sprint(k.Interface()), fieldName, v, currentVal); err != nil {
 			errs = append(errs, err)
 			continue
 		}
@@ -1135,12 +1116,9 @@ func (d *Decoder) decodeFunc(name string, data interface{}, val reflect.Value) e
 	// into that. Then set the value of the pointer to this type.
 	dataVal := reflect.Indirect(reflect.ValueOf(data))
 	if val.Type() != dataVal.Type() {
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
-				name, val.Type(), dataVal.Type(), data),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s' expected type '%s', got unconvertible type '%s', value: '%v'",
+			name, val.Type(), dataVal.Type(), data))
 	}
 	val.Set(dataVal)
 	return nil
@@ -1181,12 +1159,9 @@ func (d *Decoder) decodeSlice(name string, data interface{}, val reflect.Value)
 			}
 		}
 
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s': source data must be an array or slice, got %s",
-				name, dataValKind),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf(
+			"'%s': source data must be an array or slice, got %s",
+			name, dataValKind))
 	}
 
 	// If the input value is nil, then don't allocate since empty != nil
@@ -1213,7 +1188,7 @@ func (d *Decoder) decodeSlice(name string, data interface{}, val reflect.Value)
 		currentField := valSlice.Index(i)
 
 		fieldName := name + "[" + strconv.Itoa(i) + "]"
-		if err := d.decode(fieldName, currentData, currentField); err != nil {
+		if err := d.decodeKey(strconv.Itoa(i), fieldName, currentData, currentField); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1258,21 +1233,14 @@ func (d *Decoder) decodeArray(name string, data interface{}, val reflect.Value)
 				}
 			}
 
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf(
-					"'%s': source data must be an array or slice, got %s",
-					name, dataValKind),
-			)
-
+			return d.fieldError(val.Type(), data, fmt.Errorf(
+				"'%s': source data must be an array or slice, got %s",
+				name, dataValKind))
 		}
 		if dataVal.Len() > arrayType.Len() {
-			return errors.Join(
-				ErrDecoding,
-				fmt.Errorf(
-					"'%s': expected source data to have length less or equal to %d, got %d",
-					name, arrayType.Len(), dataVal.Len()),
-			)
+			return d.fieldError(val.Type(), data, fmt.Errorf(
+				"'%s': expected source data to have length less or equal to %d, got %d",
+				name, arrayType.Len(), dataVal.Len()))
 		}
 
 		// Make a new array to hold our result, same size as the original data.
@@ -1287,7 +1255,7 @@ func (d *Decoder) decodeArray(name string, data interface{}, val reflect.Value)
 		currentField := valArray.Index(i)
 
 		fieldName := name + "[" + strconv.Itoa(i) + "]"
-		if err := d.decode(fieldName, currentData, currentField); err != nil {
+		if err := d.decodeKey(strconv.Itoa(i), fieldName, currentData, currentField); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1342,10 +1310,7 @@ func (d *Decoder) decodeStruct(name string, data interface{}, val reflect.Value)
 		return result
 
 	default:
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf("'%s' expected a map, got '%s'", name, dataVal.Kind()),
-		)
+		return d.fieldError(val.Type(), data, fmt.Errorf("'%s' expected a map, got '%s'", name, dataVal.Kind()))
 	}
 }
 
@@ -1353,12 +1318,9 @@ func (d *Decoder) decodeStruct(name string, data interface{}, val reflect.Value)
 func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) error {
 	dataValType := dataVal.Type()
 	if kind := dataValType.Key().Kind(); kind != reflect.String && kind != reflect.Interface {
-		return errors.Join(
-			ErrDecoding,
-			fmt.Errorf(
-				"'%s' needs a map with string keys, has '%s' keys",
-				name, dataValType.Key().Kind()),
-		)
+		return d.fieldError(val.Type(), dataVal.Interface(), fmt.Errorf(
+			"'%s' needs a map with string keys, has '%s' keys",
+			name, dataValType.Key().Kind()))
 	}
 
 	dataValKeys := make(map[reflect.Value]struct{})
@@ -1368,7 +1330,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		dataValKeysUnused[dataValKey.Interface()] = struct{}{}
 	}
 
-	targetValKeysUnused := make(map[interface{}]struct{})
+	targetValKeysUnused := make(map[interface{}]reflect.Type)
 	var errs []error
 
 	// This slice will keep track of all the structs we'll be decoding.
@@ -1477,7 +1439,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 			if !rawMapVal.IsValid() {
 				// There was no matching key in the map for the value in
 				// the struct. Remember it for potential errors and metadata.
-				targetValKeysUnused[fieldName] = struct{}{}
+				targetValKeysUnused[fieldName] = field.Type
 				continue
 			}
 		}
@@ -1502,7 +1464,7 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 			fieldName = name + "." + fieldName
 		}
 
-		if err := d.decode(fieldName, rawMapVal.Interface(), fieldValue); err != nil {
+		if err := d.decodeKey(fmt.Sprint(rawMapKey.Interface()), fieldName, rawMapVal.Interface(), fieldValue); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -1533,8 +1495,16 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		}
 		sort.Strings(keys)
 
-		err := fmt.Errorf("'%s' has invalid keys: %s", name, strings.Join(keys, ", "))
-		errs = append(errs, ErrDecoding, err)
+		// Each key is reported individually so the problems can be traced
+		// back to their source.
+		for _, key := range keys {
+			err := &FieldError{
+				Path:  append(d.path(), key),
+				Value: dataVal.MapIndex(reflect.ValueOf(key)).Interface(),
+				Err:   fmt.Errorf("'%s' has invalid keys: %s", name, key),
+			}
+			errs = append(errs, err)
+		}
 	}
 
 	if d.config.ErrorUnset && len(targetValKeysUnused) > 0 {
@@ -1544,8 +1514,15 @@ func (d *Decoder) decodeStructFromMap(name string, dataVal, val reflect.Value) e
 		}
 		sort.Strings(keys)
 
-		err := fmt.Errorf("'%s' has unset fields: %s", name, strings.Join(keys, ", "))
-		errs = append(errs, ErrDecoding, err)
+		for _, key := range keys {
+			err := &FieldError{
+				Path:     append(d.path(), key),
+				Expected: targetValKeysUnused[key],
+				Missing:  true,
+				Err:      fmt.Errorf("'%s' has unset fields: %s", name, key),
+			}
+			errs = append(errs, err)
+		}
 	}
 
 	if len(errs) > 0 {
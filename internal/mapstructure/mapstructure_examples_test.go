@@ -66,15 +66,10 @@ func ExampleDecode_errors() {
 
 	fmt.Println(err.Error())
 	// Output:
-	// error decoding
 	// 'Name' expected type 'string', got unconvertible type 'int', value: '123'
-	// error decoding
 	// 'Age' expected type 'int', got unconvertible type 'string', value: 'bad value'
-	// error decoding
 	// 'Emails[0]' expected type 'string', got unconvertible type 'int', value: '1'
-	// error decoding
 	// 'Emails[1]' expected type 'string', got unconvertible type 'int', value: '2'
-	// error decoding
 	// 'Emails[2]' expected type 'string', got unconvertible type 'int', value: '3'
 }
 
@@ -8,6 +8,7 @@ package mapstructure
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"reflect"
 	"sort"
@@ -2788,6 +2789,138 @@ func testArrayInput(t *testing.T, input map[string]interface{}, expected *Array)
 	}
 }
 
+func TestFieldError(t *testing.T) {
+	t.Parallel()
+
+	type Inner struct {
+		Count int
+	}
+	type Outer struct {
+		Name   string `mapstructure:"name"`
+		List   []Inner
+		Lookup map[string]int
+		Unset  bool
+	}
+
+	input := map[string]interface{}{
+		"name":   []int{1},
+		"List":   []interface{}{map[string]interface{}{"Count": 1}, map[string]interface{}{"Count": "x"}},
+		"Lookup": map[string]interface{}{"a": true},
+		"extra":  "value",
+	}
+
+	var result Outer
+	decoder, err := NewDecoder(&DecoderConfig{
+		Result:      &result,
+		ErrorUnused: true,
+		ErrorUnset:  true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = decoder.Decode(input)
+	if !errors.Is(err, ErrDecoding) {
+		t.Fatalf("expected ErrDecoding, got: %v", err)
+	}
+
+	var got []*FieldError
+	var walk func(error)
+	walk = func(e error) {
+		if fe, ok := e.(*FieldError); ok { //nolint:errorlint
+			got = append(got, fe)
+			return
+		}
+		if joined, ok := e.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+		}
+	}
+	walk(err)
+
+	sort.Slice(got, func(i, j int) bool {
+		return strings.Join(got[i].Path, ".") < strings.Join(got[j].Path, ".")
+	})
+
+	expected := []struct {
+		path     string
+		expected reflect.Type
+		value    interface{}
+		missing  bool
+	}{
+		{path: "List.1.Count", expected: reflect.TypeOf(0), value: "x"},
+		{path: "Lookup.a", expected: reflect.TypeOf(0), value: true},
+		{path: "Unset", expected: reflect.TypeOf(true), missing: true},
+		{path: "extra", value: "value"},
+		{path: "name", expected: reflect.TypeOf(""), value: []int{1}},
+	}
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d field errors, got %d: %v", len(expected), len(got), err)
+	}
+	for i, want := range expected {
+		fe := got[i]
+		if p := strings.Join(fe.Path, "."); p != want.path {
+			t.Errorf("[%d] expected path '%s', got '%s'", i, want.path, p)
+		}
+		if fe.Expected != want.expected {
+			t.Errorf("[%d] expected type '%v', got '%v'", i, want.expected, fe.Expected)
+		}
+		if !reflect.DeepEqual(fe.Value, want.value) {
+			t.Errorf("[%d] expected value '%v', got '%v'", i, want.value, fe.Value)
+		}
+		if fe.Missing != want.missing {
+			t.Errorf("[%d] expected missing %t, got %t", i, want.missing, fe.Missing)
+		}
+		if fe.Error() != fe.Err.Error() {
+			t.Errorf("[%d] expected the message '%s', got '%s'", i, fe.Err, fe)
+		}
+	}
+}
+
+func TestFieldErrorDecodeHook(t *testing.T) {
+	t.Parallel()
+
+	type Outer struct {
+		Port int
+	}
+
+	input := map[string]interface{}{
+		"Port": "eighty",
+	}
+
+	var result Outer
+	decoder, err := NewDecoder(&DecoderConfig{
+		Result: &result,
+		DecodeHook: func(from, to reflect.Type, data interface{}) (interface{}, error) {
+			if to.Kind() == reflect.Int && from.Kind() == reflect.String {
+				return nil, errors.New("not a number")
+			}
+			return data, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = decoder.Decode(input)
+
+	var fe *FieldError
+	if !errors.As(err, &fe) {
+		t.Fatalf("expected a FieldError, got: %v", err)
+	}
+	if p := strings.Join(fe.Path, "."); p != "Port" {
+		t.Errorf("expected path 'Port', got '%s'", p)
+	}
+	if fe.Expected != reflect.TypeOf(0) {
+		t.Errorf("expected type 'int', got '%v'", fe.Expected)
+	}
+	if fe.Value != "eighty" {
+		t.Errorf("expected value 'eighty', got '%v'", fe.Value)
+	}
+}
+
 func stringPtr(v string) *string              { return &v }
 func intPtr(v int) *int                       { return &v }
 func uintPtr(v uint) *uint                    { return &v }
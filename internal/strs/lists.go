@@ -3,6 +3,14 @@
 
 package strs
 
+// Append returns a new list with the string added to the end without altering
+// the original list.
+func Append(list []string, s string) []string {
+	rv := make([]string, len(list), len(list)+1)
+	copy(rv, list)
+	return append(rv, s)
+}
+
 // Contains returns if a string is in the list.
 func Contains(list []string, want string) bool {
 	for _, s := range list {
@@ -9,6 +9,21 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestAppend(t *testing.T) {
+	assert := assert.New(t)
+
+	list := make([]string, 2, 10)
+	list[0], list[1] = "foo", "bar"
+
+	got := Append(list, "goo")
+	other := Append(list, "car")
+
+	assert.Equal([]string{"foo", "bar", "goo"}, got)
+	assert.Equal([]string{"foo", "bar", "car"}, other)
+	assert.Equal([]string{"foo", "bar"}, list)
+	assert.Equal([]string{"goo"}, Append(nil, "goo"))
+}
+
 func TestContains(t *testing.T) {
 	tests := []struct {
 		description string
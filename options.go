@@ -45,9 +45,12 @@ type Option interface {
 type options struct {
 	// Settings where there are one.
 	disableAutoCompile bool
+	explainMerges      bool
+	trackProvenance    bool
 	keyDelimiter       string
 	sorter             RecordSorter
 	hasher             Hasher
+	notifier           Notifier
 
 	// Codecs where there can be many.
 	decoders *codecRegistry[decoder.Decoder]
@@ -69,6 +72,9 @@ type options struct {
 	expansions    []expand
 	exapansionMax int
 
+	// Schemas to validate the compiled tree against; there can be many.
+	schemas []schemaCheck
+
 	// Hints are special options that check that the configuration makes sense;
 	// there can be many.
 	hints []func(*options) error
@@ -409,6 +415,63 @@ func (a disableAutoCompileOption) String() string {
 	return print.P("AutoCompile", print.BoolSilentTrue(bool(a)))
 }
 
+// ExplainMerges instructs the compilation to record the keys each record
+// altered and how they were altered in the [Explanation] if enable is true or
+// omitted.  Passing an enable value of false disables the extra behavior.
+//
+// The enable bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+//
+// # Default
+//
+// ExplainMerges is disabled.
+func ExplainMerges(enable ...bool) Option {
+	enable = append(enable, true)
+	return explainMergesOption(enable[0])
+}
+
+type explainMergesOption bool
+
+func (e explainMergesOption) apply(opts *options) error {
+	opts.explainMerges = bool(e)
+	return nil
+}
+
+func (_ explainMergesOption) ignoreDefaults() bool { return false }
+func (e explainMergesOption) String() string {
+	return print.P("ExplainMerges", print.BoolSilentTrue(bool(e)))
+}
+
+// TrackProvenance instructs the compilation to record the history of how
+// each value came to be so it is available via [Config.Provenance] if enable
+// is true or omitted.  Passing an enable value of false disables the extra
+// behavior.
+//
+// The enable bool value is optional & assumed to be `true` if omitted.  The
+// first specified value is used if provided.  A value of `false` disables the
+// option.
+//
+// # Default
+//
+// TrackProvenance is disabled.
+func TrackProvenance(enable ...bool) Option {
+	enable = append(enable, true)
+	return trackProvenanceOption(enable[0])
+}
+
+type trackProvenanceOption bool
+
+func (t trackProvenanceOption) apply(opts *options) error {
+	opts.trackProvenance = bool(t)
+	return nil
+}
+
+func (_ trackProvenanceOption) ignoreDefaults() bool { return false }
+func (t trackProvenanceOption) String() string {
+	return print.P("TrackProvenance", print.BoolSilentTrue(bool(t)))
+}
+
 // ConfigIs provides a strict field/key mapper that converts the config
 // values from the specified nomenclature into the go structure name.
 //
@@ -964,11 +1027,102 @@ func (m optionsOption) String() string {
 //
 // # For windows implementations:
 //
-// StdCfgLayout doesn't support a shared windows layout today.  One is welcome.
+// The first individual configuration file or 'conf.d' directory found is used.
+// Once a set of configuration file(s) has been found any configuration files
+// included afterward are ignored.
+//
+// The order the files/directories are searched.
+//
+//  1. The provided files in the argument list.  If any are provided,
+//     configuration must be found exclusively here.
+//
+// 2. Any files matching this path (if it exists) and glob:
+//
+//   - %LocalAppData%\<appName>\<appName>.*
+//
+// 3. Any files found in this directory (if it exists):
+//
+//   - %LocalAppData%\<appName>\conf.d\
+//
+// 4. Any files matching this path (if it exists) and glob:
+//
+//   - %AppData%\<appName>\<appName>.*
+//
+// 5. Any files found in this directory (if it exists):
+//
+//   - %AppData%\<appName>\conf.d\
+//
+// 6. Any files matching this path (if it exists) and glob:
+//
+//   - %ProgramData%\<appName>\<appName>.*
+//
+// 7. Any files found in this directory (if it exists):
+//
+//   - %ProgramData%\<appName>\conf.d\
 func StdCfgLayout(appName string, files ...string) Option {
 	return stdCfgLayout(appName, files)
 }
 
+// XDGCfgLayout is like [StdCfgLayout], but searches the locations defined by
+// the XDG Base Directory Specification instead of the home and /etc
+// directories.
+//
+// The first individual configuration file or 'conf.d' directory found is used.
+// Once a set of configuration file(s) has been found any configuration files
+// included afterward are ignored.
+//
+// The order the files/directories are searched.
+//
+//  1. The provided files in the argument list.  If any are provided,
+//     configuration must be found exclusively here.
+//
+// 2. Any files matching this path (if it exists) and glob:
+//
+//   - $XDG_CONFIG_HOME/<appName>/<appName>.*
+//
+// 3. Any files found in this directory (if it exists):
+//
+//   - $XDG_CONFIG_HOME/<appName>/conf.d/
+//
+// 4. For each directory in $XDG_CONFIG_DIRS, any files matching this path
+// (if it exists) and glob, then any files found in the 'conf.d' directory
+// (if it exists):
+//
+//   - <dir>/<appName>/<appName>.*
+//   - <dir>/<appName>/conf.d/
+//
+// If $XDG_CONFIG_HOME is not set, $HOME/.config is used.  If $XDG_CONFIG_DIRS
+// is not set, /etc/xdg is used.  Relative paths in either are ignored.
+//
+// XDGCfgLayout is not supported on windows or android.
+func XDGCfgLayout(appName string, files ...string) Option {
+	return xdgCfgLayout(appName, files)
+}
+
+// DropInLayout adds the drop-in configuration files for an application using
+// a layout modeled on systemd.  This allows packagers to provide the vendor
+// defaults and administrators to override them one file at a time.
+//
+// The files are collected from these directories (if they exist), listed
+// from the lowest to the highest precedence:
+//
+//   - /usr/lib/<appName>/<appName>.d/
+//   - /run/<appName>/<appName>.d/
+//   - /etc/<appName>/<appName>.d/
+//
+// A file in a directory with a higher precedence shadows the file with the
+// same name in the directories with a lower precedence.  An empty file or a
+// link to /dev/null masks the file it shadows entirely.  Subdirectories are
+// not examined.
+//
+// The files found are sorted with all the other records, so the default of
+// [SortRecordsNaturally] determines the order they are merged in.
+//
+// DropInLayout is not supported on windows or android.
+func DropInLayout(appName string) Option {
+	return dropInLayout(appName)
+}
+
 // SetMaxExpansions provides a way to set the maximum number of expansions
 // allowed before a recursion error is returned.  The value must be greater
 // than 0.
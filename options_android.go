@@ -8,3 +8,11 @@ import "fmt"
 func stdCfgLayout(appName string, files []string) Option {
 	return WithError(fmt.Errorf("%v: StdCfgLayout() on android", ErrUnsupported))
 }
+
+func xdgCfgLayout(appName string, files []string) Option {
+	return WithError(fmt.Errorf("%w: XDGCfgLayout() on android", ErrUnsupported))
+}
+
+func dropInLayout(appName string) Option {
+	return WithError(fmt.Errorf("%w: DropInLayout() on android", ErrUnsupported))
+}
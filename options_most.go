@@ -13,8 +13,6 @@ import (
 	"strings"
 )
 
-const confDirName = "conf.d"
-
 func stdCfgLayout(appName string, files []string) Option {
 	var l stdLocations
 	l.Populate(appName)
@@ -22,11 +20,18 @@ func stdCfgLayout(appName string, files []string) Option {
 	return nonWinStdCfgLayout(appName, files, l)
 }
 
-type stdLocations struct {
-	local fs.FS
-	home  fs.FS
-	etc   fs.FS
-	root  fs.FS
+func xdgCfgLayout(appName string, files []string) Option {
+	var l stdLocations
+	l.Populate(appName)
+
+	return nonWinXDGCfgLayout(appName, files, l)
+}
+
+func dropInLayout(appName string) Option {
+	var l stdLocations
+	l.Populate(appName)
+
+	return nonWinDropInLayout(appName, l)
 }
 
 func (s *stdLocations) Populate(name string) {
@@ -34,50 +39,72 @@ func (s *stdLocations) Populate(name string) {
 	s.root = os.DirFS("/")
 	s.etc = os.DirFS("/" + filepath.Join("etc", name))
 
-	if home := os.Getenv("HOME"); home != "" {
+	dropIn := name + ".d"
+	s.dropIns = []fs.FS{
+		os.DirFS("/" + filepath.Join("usr", "lib", name, dropIn)),
+		os.DirFS("/" + filepath.Join("run", name, dropIn)),
+		os.DirFS("/" + filepath.Join("etc", name, dropIn)),
+	}
+
+	home := os.Getenv("HOME")
+	if home != "" {
 		s.home = os.DirFS(filepath.Join(home, "."+name))
 	}
-}
 
-func nonWinStdCfgLayout(appName string, files []string, paths stdLocations) Option {
-	if appName == "" {
-		return WithError(fmt.Errorf("%w: StdCfgLayout appName cannot be empty", ErrInvalidInput))
+	// The XDG Base Directory Specification ignores relative paths and uses
+	// the defaults instead.
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if !filepath.IsAbs(configHome) {
+		configHome = ""
+		if home != "" {
+			configHome = filepath.Join(home, ".config")
+		}
 	}
-	if strings.Contains(appName, string(filepath.Separator)) {
-		return WithError(fmt.Errorf("%w: StdCfgLayout appName cannot contain character '%s'", ErrInvalidInput, string(filepath.Separator)))
+	if configHome != "" {
+		s.configHome = os.DirFS(filepath.Join(configHome, name))
 	}
 
-	// Prune out any empty files.
-	actualFiles := make([]string, 0, len(files))
-	for _, file := range files {
-		if file != "" {
-			actualFiles = append(actualFiles, file)
+	configDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if configDirs == "" {
+		configDirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(configDirs) {
+		if filepath.IsAbs(dir) {
+			s.configDirs = append(s.configDirs, os.DirFS(filepath.Join(dir, name)))
 		}
 	}
+}
 
-	if len(actualFiles) > 0 {
-		return AddJumbledHalt(paths.root, paths.local, actualFiles...)
-	}
+func nonWinStdCfgLayout(appName string, files []string, paths stdLocations) Option {
+	return cfgLayout("StdCfgLayout", appName, string(filepath.Separator), files, paths,
+		paths.local, paths.home, paths.etc)
+}
 
-	single := appName + ".*"
+func nonWinXDGCfgLayout(appName string, files []string, paths stdLocations) Option {
+	dirs := append([]fs.FS{paths.local, paths.configHome}, paths.configDirs...)
+	return cfgLayout("XDGCfgLayout", appName, string(filepath.Separator), files, paths, dirs...)
+}
 
-	// The order of the options matters
-	opts := []Option{
-		AddFilesHalt(paths.local, single),
-		AddTreeHalt(paths.local, confDirName),
+func nonWinDropInLayout(appName string, paths stdLocations) Option {
+	if appName == "" {
+		return WithError(fmt.Errorf("%w: DropInLayout appName cannot be empty", ErrInvalidInput))
 	}
-
-	if paths.home != nil {
-		opts = append(opts,
-			AddFilesHalt(paths.home, single),
-			AddTreeHalt(paths.home, confDirName),
-		)
+	if strings.Contains(appName, string(filepath.Separator)) {
+		return WithError(fmt.Errorf("%w: DropInLayout appName cannot contain character '%s'", ErrInvalidInput, string(filepath.Separator)))
 	}
 
-	opts = append(opts,
-		AddFilesHalt(paths.etc, single),
-		AddTreeHalt(paths.etc, confDirName),
-	)
+	opts := make([]Option, 0, len(paths.dropIns))
+	for i, dir := range paths.dropIns {
+		opts = append(opts, &groupOption{
+			name: "DropInLayout",
+			grp: filegroup{
+				fs:      dir,
+				paths:   []string{"."},
+				shadows: paths.dropIns[i+1:],
+				masks:   true,
+			},
+		})
+	}
 
-	return NamedOptions("StdCfgLayout", opts...)
+	return NamedOptions("DropInLayout", opts...)
 }
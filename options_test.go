@@ -12,6 +12,7 @@ import (
 	"testing/fstest"
 
 	"github.com/goschtalt/goschtalt/internal/fspath"
+	"github.com/goschtalt/goschtalt/internal/jsonschema"
 	"github.com/goschtalt/goschtalt/pkg/decoder"
 	"github.com/goschtalt/goschtalt/pkg/encoder"
 	"github.com/stretchr/testify/assert"
@@ -261,6 +262,47 @@ func TestOptions(t *testing.T) {
 			goal: options{
 				disableAutoCompile: true,
 			},
+		}, {
+			description: "ExplainMerges()",
+			opt:         ExplainMerges(),
+			str:         "ExplainMerges()",
+			goal: options{
+				explainMerges: true,
+			},
+		}, {
+			description: "ExplainMerges(false)",
+			opt:         ExplainMerges(false),
+			str:         "ExplainMerges( false )",
+		}, {
+			description: "TrackProvenance()",
+			opt:         TrackProvenance(),
+			str:         "TrackProvenance()",
+			goal: options{
+				trackProvenance: true,
+			},
+		}, {
+			description: "TrackProvenance(false)",
+			opt:         TrackProvenance(false),
+			str:         "TrackProvenance( false )",
+		}, {
+			description: "ValidateSchemaAt( 'foo', {} )",
+			opt:         ValidateSchemaAt("foo", []byte(`{"type": "object"}`)),
+			str:         "ValidateSchemaAt( 'foo' )",
+			goal: options{
+				schemas: []schemaCheck{
+					{
+						key: "foo",
+						schema: &jsonschema.Schema{
+							Type: jsonschema.Types{jsonschema.TypeObject},
+						},
+					},
+				},
+			},
+		}, {
+			description: "ValidateSchemaAt( 'foo', invalid )",
+			opt:         ValidateSchemaAt("foo", []byte(`{"pattern": "("}`)),
+			str:         "ValidateSchemaAt( 'foo' )",
+			expectErr:   ErrInvalidInput,
 		}, {
 			description: "SetKeyDelimiter( . )",
 			opt:         SetKeyDelimiter("."),
@@ -3,8 +3,41 @@
 
 package goschtalt
 
-import "fmt"
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
 
 func stdCfgLayout(appName string, files []string) Option {
-	return WithError(fmt.Errorf("%v: StdCfgLayout() on windows", ErrUnsupported))
+	var l stdLocations
+	l.Populate(appName)
+
+	return winStdCfgLayout(appName, files, l)
+}
+
+func xdgCfgLayout(appName string, files []string) Option {
+	return WithError(fmt.Errorf("%w: XDGCfgLayout() on windows", ErrUnsupported))
+}
+
+func (s *stdLocations) Populate(name string) {
+	s.local = os.DirFS(".")
+
+	// Absolute paths are relative to the root of the current volume.
+	wd, _ := os.Getwd()
+	s.root = os.DirFS(filepath.VolumeName(wd) + string(filepath.Separator))
+
+	if dir := os.Getenv("LocalAppData"); dir != "" {
+		s.localAppData = os.DirFS(filepath.Join(dir, name))
+	}
+	if dir := os.Getenv("AppData"); dir != "" {
+		s.appData = os.DirFS(filepath.Join(dir, name))
+	}
+	if dir := os.Getenv("ProgramData"); dir != "" {
+		s.programData = os.DirFS(filepath.Join(dir, name))
+	}
+}
+
+func dropInLayout(appName string) Option {
+	return WithError(fmt.Errorf("%w: DropInLayout() on windows", ErrUnsupported))
 }
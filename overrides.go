@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// AddOverrides adds a list of key=value strings that override individual
+// values in the configuration.  The recordName is used for sorting the
+// overrides relative to the other records, and the overrides are applied to
+// the configuration that has been merged from the records before it.
+//
+// Unlike the other records, the overrides are not merged.  Each override
+// replaces exactly the value the key refers to, so a single item in an array
+// may be changed.  The keys are split into parts using the key delimiter and
+// may include array index segments:
+//   - servers[1].port=8080 replaces the port of the second server.
+//   - servers[+]=example.com appends a new server to the array.
+//
+// The values are converted into the best matching type using
+// [meta.StringToBestType].
+func AddOverrides(recordName string, list []string) Option {
+	return &overridesOption{
+		text:       print.P("AddOverrides", print.String(recordName), print.Strings(list)),
+		recordName: recordName,
+		list:       list,
+	}
+}
+
+type overridesOption struct {
+	// The text to use when String() is called.
+	text string
+
+	// The record name.
+	recordName string
+
+	// The key=value strings.
+	list []string
+}
+
+func (o overridesOption) apply(opts *options) error {
+	if len(o.recordName) == 0 {
+		return fmt.Errorf("%w: a recordName with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	pairs := make([]keyValue, 0, len(o.list))
+	for _, item := range o.list {
+		key, val, found := strings.Cut(item, "=")
+		if key == "" || !found {
+			return fmt.Errorf("%w: override '%s' must be in the form key=value", ErrInvalidInput, item)
+		}
+		pairs = append(pairs, keyValue{key: key, val: val})
+	}
+
+	opts.values = append(opts.values, record{
+		name: o.recordName,
+		overrides: &overrides{
+			origin: "override",
+			pairs:  pairs,
+		},
+	})
+	return nil
+}
+
+func (_ overridesOption) ignoreDefaults() bool {
+	return false
+}
+
+func (o overridesOption) String() string {
+	return o.text
+}
+
+// keyValue is a key and the value to set it to.
+type keyValue struct {
+	key string
+	val string
+}
+
+// overrides are the values applied directly to a configuration tree instead of
+// being merged with it.
+type overrides struct {
+	// The origin of each value is the origin followed by ':' and the key.
+	origin string
+
+	pairs []keyValue
+}
+
+// applyTo applies the overrides to a copy of the tree, reporting the values
+// set or replaced.
+func (o *overrides) applyTo(tree meta.Object, delimiter string, reporter func(meta.MergeEvent)) (meta.Object, error) {
+	rv := tree.Clone()
+	for _, pair := range o.pairs {
+		var err error
+		rv, err = rv.AddPath(delimiter, pair.key, meta.StringToBestType(pair.val),
+			meta.Origin{File: o.origin + ":" + pair.key})
+		if err != nil {
+			return meta.Object{}, fmt.Errorf("%w: override '%s': %w", ErrInvalidInput, pair.key, err)
+		}
+	}
+
+	if reporter == nil {
+		return rv, nil
+	}
+
+	diff := tree.Diff(rv)
+	for _, changes := range []struct {
+		list   []meta.Difference
+		action string
+	}{
+		{list: diff.Added, action: meta.ActionSet},
+		{list: diff.Changed, action: meta.ActionReplace},
+	} {
+		for _, d := range changes.list {
+			obj, err := rv.Fetch(d.Path, "")
+			if err != nil {
+				continue
+			}
+			reporter(meta.MergeEvent{
+				Path:   d.Path,
+				Action: changes.action,
+				Result: obj,
+			})
+		}
+	}
+
+	return rv, nil
+}
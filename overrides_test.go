@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddOverrides(t *testing.T) {
+	tests := []struct {
+		description string
+		recordName  string
+		list        []string
+		str         string
+		expected    []keyValue
+		expectedErr error
+	}{
+		{
+			description: "a few overrides",
+			recordName:  "overrides",
+			list:        []string{"a.b=1", "servers[0].port=a=b", "empty="},
+			str:         "AddOverrides( 'overrides', 'a.b=1', 'servers[0].port=a=b', 'empty=' )",
+			expected: []keyValue{
+				{key: "a.b", val: "1"},
+				{key: "servers[0].port", val: "a=b"},
+				{key: "empty", val: ""},
+			},
+		}, {
+			description: "no record name",
+			list:        []string{"a=1"},
+			str:         "AddOverrides( '', 'a=1' )",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "no value",
+			recordName:  "overrides",
+			list:        []string{"a"},
+			str:         "AddOverrides( 'overrides', 'a' )",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "no key",
+			recordName:  "overrides",
+			list:        []string{"=1"},
+			str:         "AddOverrides( 'overrides', '=1' )",
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			opt := AddOverrides(tc.recordName, tc.list)
+			assert.Equal(tc.str, opt.String())
+			assert.False(opt.ignoreDefaults())
+
+			var opts options
+			err := opt.apply(&opts)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+			require.Len(opts.values, 1)
+			assert.Equal(tc.recordName, opts.values[0].name)
+			require.NotNil(opts.values[0].overrides)
+			assert.Equal(tc.expected, opts.values[0].overrides.pairs)
+		})
+	}
+}
+
+func TestOverridesApplyTo(t *testing.T) {
+	at := func(key string) []meta.Origin {
+		return []meta.Origin{{File: "override:" + key}}
+	}
+	file := []meta.Origin{{File: "file"}}
+
+	start := meta.Object{
+		Origins: file,
+		Map: map[string]meta.Object{
+			"name": {Origins: file, Value: "abc"},
+			"servers": {
+				Origins: file,
+				Array: []meta.Object{
+					{
+						Origins: file,
+						Map: map[string]meta.Object{
+							"port": {Origins: file, Value: int64(80)},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		description string
+		pairs       []keyValue
+		expected    meta.Object
+		events      []meta.MergeEvent
+		expectedErr error
+	}{
+		{
+			description: "replace and append items in an array",
+			pairs: []keyValue{
+				{key: "servers[0].port", val: "8080"},
+				{key: "servers[+].port", val: "443"},
+				{key: "debug", val: "true"},
+			},
+			expected: meta.Object{
+				Origins: file,
+				Map: map[string]meta.Object{
+					"name": {Origins: file, Value: "abc"},
+					"servers": {
+						Origins: file,
+						Array: []meta.Object{
+							{
+								Origins: file,
+								Map: map[string]meta.Object{
+									"port": {Origins: at("servers[0].port"), Value: int64(8080)},
+								},
+							}, {
+								Origins: at("servers[+].port"),
+								Map: map[string]meta.Object{
+									"port": {Origins: at("servers[+].port"), Value: int64(443)},
+								},
+							},
+						},
+					},
+					"debug": {Origins: at("debug"), Value: true},
+				},
+			},
+			events: []meta.MergeEvent{
+				{
+					Path:   []string{"debug"},
+					Action: meta.ActionSet,
+					Result: meta.Object{Origins: at("debug"), Value: true},
+				}, {
+					Path:   []string{"servers", "1", "port"},
+					Action: meta.ActionSet,
+					Result: meta.Object{Origins: at("servers[+].port"), Value: int64(443)},
+				}, {
+					Path:   []string{"servers", "0", "port"},
+					Action: meta.ActionReplace,
+					Result: meta.Object{Origins: at("servers[0].port"), Value: int64(8080)},
+				},
+			},
+		}, {
+			description: "an index that is out of bounds",
+			pairs: []keyValue{
+				{key: "servers[5].port", val: "8080"},
+			},
+			expectedErr: meta.ErrArrayOutOfBounds,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			before := start.Clone()
+			o := overrides{
+				origin: "override",
+				pairs:  tc.pairs,
+			}
+
+			var events []meta.MergeEvent
+			got, err := o.applyTo(start, ".", func(e meta.MergeEvent) {
+				events = append(events, e)
+			})
+
+			// The original tree is never altered.
+			assert.Equal(before, start)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.ErrorIs(err, ErrInvalidInput)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+			assert.Equal(tc.events, events)
+		})
+	}
+}
+
+func TestAddOverridesEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	gs, err := New(
+		AddValue("10-values", Root, map[string]any{
+			"servers": []any{
+				map[string]any{"host": "a", "port": 80},
+				map[string]any{"host": "b", "port": 80},
+			},
+		}),
+		AddOverrides("20-overrides", []string{"servers[1].port=8080", "servers[+].host=c"}),
+		ExplainMerges(),
+		AutoCompile(),
+	)
+	require.NoError(err)
+
+	type server struct {
+		Host string `goschtalt:"host"`
+		Port int    `goschtalt:"port"`
+	}
+
+	got, err := Unmarshal[[]server](gs, "servers")
+	require.NoError(err)
+	assert.Equal([]server{
+		{Host: "a", Port: 80},
+		{Host: "b", Port: 8080},
+		{Host: "c"},
+	}, got)
+
+	records := gs.Explain().Records
+	require.Len(records, 2)
+	assert.Equal("20-overrides", records[1].Name)
+	assert.Equal([]ExplanationMerge{
+		{Key: "servers.1.port", Action: meta.ActionReplace},
+		{Key: "servers.2.host", Action: meta.ActionSet},
+	}, gs.Explain().Merges[1])
+}
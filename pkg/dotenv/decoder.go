@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/internal/casbab"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ decoder.Decoder = (*Decoder)(nil)
+
+// ErrSyntax is returned when the document isn't a valid dotenv file.
+var ErrSyntax = errors.New("dotenv syntax error")
+
+// DefaultSeparator is the separator used to split the keys if one isn't
+// specified.
+const DefaultSeparator = "__"
+
+// secretCmd is the goschtalt instruction that marks a value as secret.
+const secretCmd = "((secret))"
+
+// Decoder is a dotenv file decoder that records the origin of each key.
+//
+// The format supported is:
+//   - Lines with KEY=value pairs.
+//   - An optional 'export' prefix before the key.
+//   - Lines starting with '#' are comments.  Unquoted values end at a '#'
+//     preceded by whitespace.
+//   - Values in double quotes may contain the escape sequences \n, \r, \t,
+//     \", \\ and \$, and may span several lines.
+//   - Values in single quotes are used as is and may span several lines.
+//
+// All values are strings and variables (like ${VAR}) are left as is so they
+// may be expanded by goschtalt.  If a key is repeated, the last value is used.
+type Decoder struct {
+	// Separator is used to split the keys into nested maps.  If not
+	// specified, DefaultSeparator is used.
+	Separator string
+
+	// Case is the format the parts of the keys are converted into.  The
+	// formats are the same as those accepted by goschtalt.ConfigIs, for
+	// example "two_words" or "twoWords".  If not specified, the keys are used
+	// as is.
+	Case string
+
+	// Secrets are the patterns of the keys that are marked as secret, for
+	// example "*_PASSWORD".  The patterns are matched against the complete
+	// key as found in the file using the syntax of path.Match.
+	Secrets []string
+}
+
+// Extensions returns the supported extensions.
+func (d Decoder) Extensions() []string {
+	return []string{"env"}
+}
+
+// Decode decodes a dotenv file into the meta.Object tree.  A file without any
+// keys results in an empty tree.
+func (d Decoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	separator := d.Separator
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+
+	toCase := func(s string) string { return s }
+	if d.Case != "" {
+		toCase = casbab.Find(d.Case)
+		if toCase == nil {
+			return fmt.Errorf("%w: unknown case '%s'", goschtalt.ErrInvalidInput, d.Case)
+		}
+	}
+
+	for _, pattern := range d.Secrets {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: invalid secret pattern '%s': %w", goschtalt.ErrInvalidInput, pattern, err)
+		}
+	}
+
+	p := parser{
+		file: ctx.Filename,
+		data: string(b),
+	}
+
+	root := meta.Object{
+		Origins: []meta.Origin{p.origin(0)},
+		Map:     make(map[string]meta.Object),
+	}
+
+	for {
+		k, val, err := p.next()
+		if err != nil {
+			return err
+		}
+		if k.name == "" {
+			break
+		}
+
+		parts := strings.Split(k.name, separator)
+		for i := range parts {
+			parts[i] = toCase(parts[i])
+		}
+		if d.secret(k.name) {
+			parts[len(parts)-1] += secretCmd
+		}
+
+		if err := add(root.Map, parts, val, p.origin(k.pos)); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrSyntax, p.origin(k.pos), err)
+		}
+	}
+
+	if len(root.Map) == 0 {
+		*m = meta.Object{}
+		return nil
+	}
+
+	*m = root
+	return nil
+}
+
+// secret determines if the key matches one of the secret patterns.
+func (d Decoder) secret(key string) bool {
+	for _, pattern := range d.Secrets {
+		if match, _ := path.Match(pattern, key); match {
+			return true
+		}
+	}
+	return false
+}
+
+// add adds the value to the tree, creating the maps needed.
+func add(m map[string]meta.Object, parts []string, val string, origin meta.Origin) error {
+	for i, part := range parts[:len(parts)-1] {
+		child, found := m[part]
+		if !found {
+			child = meta.Object{
+				Origins: []meta.Origin{origin},
+				Map:     make(map[string]meta.Object),
+			}
+			m[part] = child
+		}
+
+		if child.Map == nil {
+			return fmt.Errorf("'%s' is already defined as a value", strings.Join(parts[:i+1], "."))
+		}
+		m = child.Map
+	}
+
+	last := parts[len(parts)-1]
+	if existing, found := m[last]; found && existing.Map != nil {
+		return fmt.Errorf("'%s' is already defined as a map", strings.Join(parts, "."))
+	}
+
+	m[last] = meta.Object{
+		Origins: []meta.Origin{origin},
+		Value:   val,
+	}
+	return nil
+}
+
+// key is a key along with where it is.
+type key struct {
+	name string
+	pos  int
+}
+
+// parser reads the key value pairs of a dotenv file.
+type parser struct {
+	file  string
+	data  string
+	pos   int
+	lines []int
+}
+
+// next returns the next key value pair or an empty key at the end of the
+// file.
+func (p *parser) next() (key, string, error) {
+	for !p.eof() {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+		if p.peek() == '#' || p.peek() == '\n' || p.peek() == '\r' {
+			p.skipLine()
+			continue
+		}
+
+		k, err := p.key()
+		if err != nil {
+			return key{}, "", err
+		}
+
+		val, err := p.value()
+		if err != nil {
+			return key{}, "", err
+		}
+		return k, val, nil
+	}
+
+	return key{}, "", nil
+}
+
+func (p *parser) key() (key, error) {
+	if strings.HasPrefix(p.data[p.pos:], "export ") || strings.HasPrefix(p.data[p.pos:], "export\t") {
+		p.pos += len("export")
+		p.skipSpace()
+	}
+
+	k := key{pos: p.pos}
+	for !p.eof() && !strings.ContainsRune("= \t\r\n#", rune(p.peek())) {
+		p.pos++
+	}
+	k.name = p.data[k.pos:p.pos]
+
+	p.skipSpace()
+	if k.name == "" {
+		return k, p.errorf(p.pos, "expected a key")
+	}
+	if p.eof() || p.peek() != '=' {
+		return k, p.errorf(p.pos, "expected '=' after the key")
+	}
+	p.pos++
+	p.skipSpace()
+
+	return k, nil
+}
+
+// value reads the value and the remainder of the line.
+func (p *parser) value() (string, error) {
+	if p.eof() {
+		return "", nil
+	}
+
+	var val string
+	switch p.peek() {
+	case '"':
+		var err error
+		if val, err = p.quoted(); err != nil {
+			return "", err
+		}
+	case '\'':
+		start := p.pos
+		end := strings.IndexByte(p.data[p.pos+1:], '\'')
+		if end < 0 {
+			return "", p.errorf(start, "unterminated string")
+		}
+		val = p.data[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+	default:
+		start := p.pos
+		for !p.eof() && p.peek() != '\n' {
+			if p.peek() == '#' && p.pos > start && strings.ContainsRune(" \t", rune(p.data[p.pos-1])) {
+				break
+			}
+			p.pos++
+		}
+		return strings.TrimSpace(p.data[start:p.pos]), p.endOfLine()
+	}
+
+	return val, p.endOfLine()
+}
+
+var escapes = map[byte]string{
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'"':  "\"",
+	'\\': "\\",
+	'$':  "$",
+}
+
+// quoted reads a double quoted value.  Unknown escape sequences are left as
+// is.
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+
+		switch c {
+		case '"':
+			return b.String(), nil
+		case '\\':
+			if !p.eof() {
+				if s, found := escapes[p.peek()]; found {
+					b.WriteString(s)
+					p.pos++
+					continue
+				}
+			}
+		}
+		b.WriteByte(c)
+	}
+
+	return "", p.errorf(start, "unterminated string")
+}
+
+// endOfLine consumes the remainder of the line, which may only contain
+// whitespace and a comment.
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	if p.eof() {
+		return nil
+	}
+	if p.peek() != '#' && p.peek() != '\n' && p.peek() != '\r' {
+		return p.errorf(p.pos, "expected the end of the line")
+	}
+	p.skipLine()
+	return nil
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	return p.data[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipLine skips past the end of the line.
+func (p *parser) skipLine() {
+	end := strings.IndexByte(p.data[p.pos:], '\n')
+	if end < 0 {
+		p.pos = len(p.data)
+		return
+	}
+	p.pos += end + 1
+}
+
+// origin converts the offset into the line and column.  Both start at 1 and
+// the column is counted in characters.
+func (p *parser) origin(offset int) meta.Origin {
+	if p.lines == nil {
+		p.lines = []int{0}
+		for i := 0; i < len(p.data); i++ {
+			if p.data[i] == '\n' {
+				p.lines = append(p.lines, i+1)
+			}
+		}
+	}
+
+	offset = min(max(offset, 0), len(p.data))
+	line := sort.Search(len(p.lines), func(i int) bool {
+		return p.lines[i] > offset
+	}) - 1
+
+	return meta.Origin{
+		File: p.file,
+		Line: line + 1,
+		Col:  utf8.RuneCountInString(p.data[p.lines[line]:offset]) + 1,
+	}
+}
+
+func (p *parser) errorf(offset int, format string, a ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrSyntax, p.origin(offset), fmt.Sprintf(format, a...))
+}
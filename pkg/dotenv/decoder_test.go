@@ -0,0 +1,219 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package dotenv
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/json"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(line, col int) []meta.Origin {
+	return []meta.Origin{{File: "file.env", Line: line, Col: col}}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description string
+		decoder     Decoder
+		in          string
+		expected    meta.Object
+		expectedErr error
+		errContains string
+	}{
+		{
+			description: "an empty document",
+			in:          "# Only a comment.\n\n   \n",
+			expected:    meta.Object{},
+		}, {
+			description: "values, quotes and comments",
+			in: `NAME=example
+export  PORT = 8080 # A comment.
+EMPTY=
+HASH=a#b
+DQ="a \"b\"\n\t\$HOME ${HOME} \q" # A comment.
+SQ='a \n ${HOME}'
+MULTI="line one
+line two"
+	ü=x
+NAME=override
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"NAME":  {Origins: at(10, 1), Value: "override"},
+					"PORT":  {Origins: at(2, 9), Value: "8080"},
+					"EMPTY": {Origins: at(3, 1), Value: ""},
+					"HASH":  {Origins: at(4, 1), Value: "a#b"},
+					"DQ":    {Origins: at(5, 1), Value: "a \"b\"\n\t$HOME ${HOME} \\q"},
+					"SQ":    {Origins: at(6, 1), Value: `a \n ${HOME}`},
+					"MULTI": {Origins: at(7, 1), Value: "line one\nline two"},
+					"ü":     {Origins: at(9, 2), Value: "x"},
+				},
+			},
+		}, {
+			description: "nested keys with the default separator",
+			in:          "DB__HOST=localhost\nDB__PORT=5432\nDB__TLS__ON=true\n",
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"DB": {
+						Origins: at(1, 1),
+						Map: map[string]meta.Object{
+							"HOST": {Origins: at(1, 1), Value: "localhost"},
+							"PORT": {Origins: at(2, 1), Value: "5432"},
+							"TLS": {
+								Origins: at(3, 1),
+								Map: map[string]meta.Object{
+									"ON": {Origins: at(3, 1), Value: "true"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a separator, case and secrets",
+			decoder: Decoder{
+				Separator: ".",
+				Case:      "two_words",
+				Secrets:   []string{"*_PASSWORD", "API.TOKEN"},
+			},
+			in: "DB.ADMIN_PASSWORD=hunter2\nAPI.TOKEN=abc\nAPI.BASE_URL=x\n",
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"db": {
+						Origins: at(1, 1),
+						Map: map[string]meta.Object{
+							"admin_password((secret))": {Origins: at(1, 1), Value: "hunter2"},
+						},
+					},
+					"api": {
+						Origins: at(2, 1),
+						Map: map[string]meta.Object{
+							"token((secret))": {Origins: at(2, 1), Value: "abc"},
+							"base_url":        {Origins: at(3, 1), Value: "x"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a key that is a value",
+			in:          "A=1\nA__B=2",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:2[1]: 'A' is already defined as a value",
+		}, {
+			description: "a key that is a map",
+			in:          "A__B=1\nA=2",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:2[1]: 'A' is already defined as a map",
+		}, {
+			description: "a missing equals",
+			in:          "\nexport KEY value",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:2[12]: expected '=' after the key",
+		}, {
+			description: "a missing key",
+			in:          "=value",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:1[1]: expected a key",
+		}, {
+			description: "an unterminated double quote",
+			in:          "A=\"abc\n",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:1[3]: unterminated string",
+		}, {
+			description: "an unterminated single quote",
+			in:          "A='abc\n",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:1[3]: unterminated string",
+		}, {
+			description: "data after the closing quote",
+			in:          "A=\"abc\" def",
+			expectedErr: ErrSyntax,
+			errContains: "file.env:1[9]: expected the end of the line",
+		}, {
+			description: "an unknown case",
+			decoder:     Decoder{Case: "invalid"},
+			in:          "A=1",
+			expectedErr: goschtalt.ErrInvalidInput,
+			errContains: "unknown case 'invalid'",
+		}, {
+			description: "an invalid secret pattern",
+			decoder:     Decoder{Secrets: []string{"["}},
+			in:          "A=1",
+			expectedErr: goschtalt.ErrInvalidInput,
+			errContains: "invalid secret pattern '['",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got meta.Object
+			ctx := decoder.Context{
+				Filename:  "file.env",
+				Delimiter: ".",
+			}
+			err := tc.decoder.Decode(ctx, []byte(tc.in), &got)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.ErrorContains(err, tc.errContains)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestDecoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"env"}, Decoder{}.Extensions())
+}
+
+func TestEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		".env": &fstest.MapFile{
+			Data: []byte("# Settings.\nexport APP__NAME=example\nAPP__DB_PASSWORD='hunter2'\n"),
+		},
+	}
+
+	gs, err := goschtalt.New(
+		goschtalt.AddDir(fs, "."),
+		goschtalt.WithDecoder(Decoder{
+			Case:    "two_words",
+			Secrets: []string{"*_PASSWORD"},
+		}),
+		goschtalt.WithEncoder(json.Encoder{}),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		Name       string `goschtalt:"name"`
+		DBPassword string `goschtalt:"db_password"`
+	}
+
+	got, err := goschtalt.Unmarshal[config](gs, "app")
+	require.NoError(err)
+	assert.Equal(config{Name: "example", DBPassword: "hunter2"}, got)
+
+	// The password is redacted when the configuration is shown.
+	out, err := gs.Marshal(goschtalt.RedactSecrets(true), goschtalt.FormatAs("json"))
+	require.NoError(err)
+	assert.Contains(string(out), "REDACTED")
+	assert.NotContains(string(out), "hunter2")
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dotenv provides a decoder for dotenv (.env) files for goschtalt that
+// only depends upon the go standard library.
+//
+// The keys are split into nested maps using a separator, so the following
+// file:
+//
+//	# The database settings.
+//	export DB__HOST=db.example.com
+//	DB__PASSWORD="hunter2"
+//
+// produces the same configuration tree as the following JSON:
+//
+//	{
+//	  "DB": {
+//	    "HOST": "db.example.com",
+//	    "PASSWORD": "hunter2"
+//	  }
+//	}
+//
+// The decoder may also convert the case of the keys and mark values as secret
+// based on the names of the keys.
+//
+// # Usage
+//
+// Add the following line to the import list & the decoder is automatically
+// registered for the 'env' extension using the default settings.
+//
+//	import (
+//		_ "github.com/goschtalt/goschtalt/pkg/dotenv"
+//	)
+//
+// Alternatively, the decoder may be configured and registered explicitly.
+//
+//	gs, err := goschtalt.New(
+//		goschtalt.ConfigIs("two_words"),
+//		goschtalt.WithDecoder(dotenv.Decoder{
+//			Separator: "__",
+//			Case:      "two_words",
+//			Secrets:   []string{"*_PASSWORD", "*_TOKEN"},
+//		}),
+//	)
+package dotenv
+
+import "github.com/goschtalt/goschtalt"
+
+func init() {
+	goschtalt.DefaultOptions = append(goschtalt.DefaultOptions,
+		goschtalt.WithDecoder(Decoder{}),
+	)
+}
@@ -0,0 +1,768 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package hcl
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ decoder.Decoder = (*Decoder)(nil)
+
+// ErrSyntax is returned when the document isn't valid or uses HCL features
+// that are not supported.
+var ErrSyntax = errors.New("hcl syntax error")
+
+// Decoder is an HCL decoder that records the origin of each attribute and
+// block.
+//
+// The origin of an attribute is the position of its name and the origin of
+// a block is the position of its type.  The maps created for the labels of
+// a block have the position of the label.  Values in tuples and objects have
+// the position where the value (or key) starts.
+//
+// A single block without labels may be combined with labelled blocks of the
+// same type.  The attributes and blocks it contains are merged with the maps
+// created for the labels, so the names may not collide.
+//
+// Numbers are decoded as int64 values if possible, otherwise as float64
+// values.  Template interpolations (like ${var}) are left as is in strings so
+// they may be expanded by goschtalt.
+type Decoder struct{}
+
+// Extensions returns the supported extensions.
+func (Decoder) Extensions() []string {
+	return []string{"hcl"}
+}
+
+// Decode decodes an HCL document into the meta.Object tree.  A document
+// without any attributes or blocks results in an empty tree.
+func (Decoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	p := parser{
+		file: ctx.Filename,
+		data: string(b),
+	}
+
+	root, err := p.body(p.origin(0), false)
+	if err != nil {
+		return err
+	}
+
+	if len(root.items) == 0 {
+		*m = meta.Object{}
+		return nil
+	}
+
+	*m = root.toObject()
+	return nil
+}
+
+// The kinds of items in a body.
+const (
+	attributeItem = iota
+	blockItem
+)
+
+// body is the contents of the document or a block.
+type body struct {
+	origin meta.Origin
+	items  map[string]*item
+}
+
+// item is an attribute or the blocks with the same type and labels along with
+// the map created for the next label of the blocks.
+type item struct {
+	kind   int
+	origin meta.Origin
+	value  meta.Object      // Used by attributes.
+	blocks []*body          // Used by blocks.
+	labels map[string]*item // Used by blocks.
+}
+
+func (b *body) toObject() meta.Object {
+	obj := meta.Object{
+		Origins: []meta.Origin{b.origin},
+		Map:     make(map[string]meta.Object, len(b.items)),
+	}
+	for key, it := range b.items {
+		obj.Map[key] = it.toObject()
+	}
+	return obj
+}
+
+func (it *item) toObject() meta.Object {
+	if it.kind != blockItem {
+		return it.value
+	}
+
+	if len(it.labels) == 0 {
+		if len(it.blocks) == 1 {
+			return it.blocks[0].toObject()
+		}
+		obj := meta.Object{
+			Origins: []meta.Origin{it.origin},
+			Array:   make([]meta.Object, len(it.blocks)),
+		}
+		for i, b := range it.blocks {
+			obj.Array[i] = b.toObject()
+		}
+		return obj
+	}
+
+	obj := meta.Object{
+		Origins: []meta.Origin{it.origin},
+		Map:     make(map[string]meta.Object, len(it.labels)),
+	}
+	for key, child := range it.labels {
+		obj.Map[key] = child.toObject()
+	}
+
+	// At most one block without labels is present, so merge its contents.
+	for _, b := range it.blocks {
+		for key, child := range b.items {
+			obj.Map[key] = child.toObject()
+		}
+	}
+	return obj
+}
+
+// mergeable determines if the blocks without labels can be merged with the
+// maps created for the labels.  Only a single block can be merged and none of
+// its attributes or blocks may have the same name as a label.
+func (it *item) mergeable() bool {
+	if len(it.labels) == 0 {
+		return true
+	}
+	if len(it.blocks) > 1 {
+		return false
+	}
+	for _, b := range it.blocks {
+		for key := range b.items {
+			if _, found := it.labels[key]; found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// name is an attribute name, block type or label along with where it is.
+type name struct {
+	text string
+	pos  int
+}
+
+// parser is a recursive descent parser of HCL documents.
+type parser struct {
+	file  string
+	data  string
+	pos   int
+	lines []int
+}
+
+// -- Structure ----------------------------------------------------------------
+
+// body reads attributes and blocks until the end of the document or the end
+// of the block.
+func (p *parser) body(origin meta.Origin, block bool) (*body, error) {
+	b := body{
+		origin: origin,
+		items:  make(map[string]*item),
+	}
+
+	for {
+		if err := p.skip(true); err != nil {
+			return nil, err
+		}
+
+		if p.eof() {
+			if block {
+				return nil, p.errorf(p.pos, "unterminated block")
+			}
+			return &b, nil
+		}
+		if p.peek() == '}' {
+			if !block {
+				return nil, p.errorf(p.pos, "unexpected '}'")
+			}
+			p.pos++
+			return &b, nil
+		}
+
+		id, err := p.identifier()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.skip(false); err != nil {
+			return nil, err
+		}
+
+		if p.consume("=") {
+			if err := p.attribute(&b, id); err != nil {
+				return nil, err
+			}
+		} else if err := p.block(&b, id); err != nil {
+			return nil, err
+		}
+
+		if err := p.endOfItem(block); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) attribute(b *body, id name) error {
+	if err := p.skip(false); err != nil {
+		return err
+	}
+
+	val, err := p.expression()
+	if err != nil {
+		return err
+	}
+
+	if _, found := b.items[id.text]; found {
+		return p.errorf(id.pos, "'%s' is already defined", id.text)
+	}
+
+	val.Origins = []meta.Origin{p.origin(id.pos)}
+	b.items[id.text] = &item{
+		kind:  attributeItem,
+		value: val,
+	}
+	return nil
+}
+
+func (p *parser) block(b *body, typ name) error {
+	var labels []name
+	for !p.eof() && p.peek() != '{' {
+		var label name
+		var err error
+		if p.peek() == '"' {
+			label.pos = p.pos
+			label.text, err = p.quoted()
+		} else {
+			label, err = p.identifier()
+		}
+		if err != nil {
+			return err
+		}
+		labels = append(labels, label)
+
+		if err := p.skip(false); err != nil {
+			return err
+		}
+	}
+
+	start := p.pos
+	if !p.consume("{") {
+		return p.errorf(p.pos, "expected '=' or '{'")
+	}
+
+	contents, err := p.body(p.origin(typ.pos), true)
+	if err != nil {
+		return err
+	}
+
+	// Each label adds another level of maps with the blocks at the end.
+	items := b.items
+	names := append([]name{typ}, labels...)
+	if _, found := items[typ.text]; !found {
+		items[typ.text] = p.blockItem(typ)
+	}
+	for i, n := range names {
+		it := items[n.text]
+		if it.kind != blockItem {
+			return p.errorf(start, "'%s' is already defined", nameString(names[:i+1]))
+		}
+
+		if i == len(names)-1 {
+			it.blocks = append(it.blocks, contents)
+		} else if _, found := it.labels[names[i+1].text]; !found {
+			it.labels[names[i+1].text] = p.blockItem(names[i+1])
+		}
+
+		if !it.mergeable() {
+			return p.errorf(start, "'%s' blocks with and without labels conflict", nameString(names[:i+1]))
+		}
+		items = it.labels
+	}
+
+	return nil
+}
+
+// blockItem returns the item for the blocks with the type or label.
+func (p *parser) blockItem(n name) *item {
+	return &item{
+		kind:   blockItem,
+		origin: p.origin(n.pos),
+		labels: make(map[string]*item),
+	}
+}
+
+func nameString(names []name) string {
+	list := make([]string, len(names))
+	for i, n := range names {
+		list[i] = n.text
+	}
+	return strings.Join(list, ".")
+}
+
+// endOfItem consumes the end of an attribute or block, which is a newline
+// or the end of the enclosing block.
+func (p *parser) endOfItem(block bool) error {
+	if err := p.skip(false); err != nil {
+		return err
+	}
+	if p.eof() || p.consume("\n") || p.consume("\r\n") || (block && p.peek() == '}') {
+		return nil
+	}
+	return p.errorf(p.pos, "expected a newline")
+}
+
+// identifier reads an identifier.
+func (p *parser) identifier() (name, error) {
+	n := name{pos: p.pos}
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+		if !unicode.IsLetter(r) && r != '_' && (p.pos == n.pos || (!unicode.IsDigit(r) && r != '-')) {
+			break
+		}
+		p.pos += size
+	}
+
+	if p.pos == n.pos {
+		return n, p.errorf(p.pos, "expected an identifier")
+	}
+	n.text = p.data[n.pos:p.pos]
+	return n, nil
+}
+
+// -- Expressions --------------------------------------------------------------
+
+// expression reads a literal value, string, heredoc, tuple or object.
+func (p *parser) expression() (meta.Object, error) {
+	if p.eof() {
+		return meta.Object{}, p.errorf(p.pos, "expected an expression")
+	}
+
+	obj := meta.Object{
+		Origins: []meta.Origin{p.origin(p.pos)},
+	}
+
+	var err error
+	c := p.peek()
+	switch {
+	case c == '"':
+		obj.Value, err = p.quoted()
+	case strings.HasPrefix(p.data[p.pos:], "<<"):
+		obj.Value, err = p.heredoc()
+	case c == '[':
+		obj.Array, err = p.tuple()
+	case c == '{':
+		obj.Map, err = p.object()
+	case c == '-' || isDigit(c):
+		obj.Value, err = p.number()
+	default:
+		var id name
+		id, err = p.identifier()
+		if err != nil {
+			return obj, p.errorf(p.pos, "expected an expression")
+		}
+
+		switch id.text {
+		case "true":
+			obj.Value = true
+		case "false":
+			obj.Value = false
+		case "null":
+		default:
+			return obj, p.errorf(id.pos, "unsupported expression '%s'; only literal values are supported", id.text)
+		}
+	}
+
+	return obj, err
+}
+
+func (p *parser) tuple() ([]meta.Object, error) {
+	start := p.pos
+	p.pos++
+
+	list := []meta.Object{}
+	for {
+		if err := p.skip(true); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated tuple")
+		}
+		if p.consume("]") {
+			return list, nil
+		}
+
+		val, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+
+		if err := p.skip(true); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated tuple")
+		}
+		if p.consume("]") {
+			return list, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf(p.pos, "expected ',' or ']' in the tuple")
+		}
+	}
+}
+
+func (p *parser) object() (map[string]meta.Object, error) {
+	start := p.pos
+	p.pos++
+
+	m := make(map[string]meta.Object)
+	for {
+		if err := p.skip(true); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated object")
+		}
+		if p.consume("}") {
+			return m, nil
+		}
+
+		var key name
+		var err error
+		if p.peek() == '"' {
+			key.pos = p.pos
+			key.text, err = p.quoted()
+		} else {
+			key, err = p.identifier()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.skip(false); err != nil {
+			return nil, err
+		}
+		if !p.consume("=") && !p.consume(":") {
+			return nil, p.errorf(p.pos, "expected '=' or ':' after the key")
+		}
+		if err := p.skip(false); err != nil {
+			return nil, err
+		}
+
+		val, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, found := m[key.text]; found {
+			return nil, p.errorf(key.pos, "'%s' is already defined", key.text)
+		}
+		val.Origins = []meta.Origin{p.origin(key.pos)}
+		m[key.text] = val
+
+		// The items are separated by commas or newlines.
+		if err := p.skip(false); err != nil {
+			return nil, err
+		}
+		if !p.consume(",") && !p.consume("\n") && !p.consume("\r\n") &&
+			!p.eof() && p.peek() != '}' {
+			return nil, p.errorf(p.pos, "expected ',', a newline or '}' in the object")
+		}
+	}
+}
+
+var numberRE = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][+-]?[0-9]+)?`)
+
+func (p *parser) number() (any, error) {
+	start := p.pos
+	tok := numberRE.FindString(p.data[p.pos:])
+	if tok == "" {
+		return nil, p.errorf(start, "invalid number")
+	}
+	p.pos += len(tok)
+
+	if i, err := strconv.ParseInt(tok, 10, 64); err == nil {
+		return i, nil
+	}
+	f, err := strconv.ParseFloat(tok, 64)
+	if err != nil {
+		return nil, p.errorf(start, "the number '%s' is out of range", tok)
+	}
+	return f, nil
+}
+
+// -- Strings ------------------------------------------------------------------
+
+// quoted reads a quoted string.  Template sequences are kept as is, except
+// for the escaped forms ($${ and %%{) which are unescaped.
+func (p *parser) quoted() (string, error) {
+	start := p.pos
+	p.pos++
+
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf(start, "unterminated string")
+		}
+
+		switch {
+		case p.consume(`"`):
+			return b.String(), nil
+		case p.consume("$${"):
+			b.WriteString("${")
+		case p.consume("%%{"):
+			b.WriteString("%{")
+		case strings.HasPrefix(p.data[p.pos:], "${"), strings.HasPrefix(p.data[p.pos:], "%{"):
+			if err := p.template(&b); err != nil {
+				return "", err
+			}
+		case p.peek() == '\\':
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+			b.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+// template copies a template sequence including any nested braces.
+func (p *parser) template(b *strings.Builder) error {
+	start := p.pos
+	depth := 0
+	for !p.eof() {
+		c := p.peek()
+		b.WriteByte(c)
+		p.pos++
+
+		switch c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case '\n':
+			return p.errorf(start, "unterminated template sequence")
+		}
+	}
+	return p.errorf(start, "unterminated template sequence")
+}
+
+var escapes = map[byte]string{
+	'n':  "\n",
+	'r':  "\r",
+	't':  "\t",
+	'"':  "\"",
+	'\\': "\\",
+}
+
+// escape reads an escape sequence.
+func (p *parser) escape(b *strings.Builder) error {
+	start := p.pos
+	p.pos++
+	if p.eof() {
+		return p.errorf(start, "unterminated string")
+	}
+
+	c := p.peek()
+	p.pos++
+	if s, found := escapes[c]; found {
+		b.WriteString(s)
+		return nil
+	}
+
+	size := 0
+	switch c {
+	case 'u':
+		size = 4
+	case 'U':
+		size = 8
+	default:
+		return p.errorf(start, "invalid escape sequence '\\%c'", c)
+	}
+
+	if p.pos+size > len(p.data) {
+		return p.errorf(start, "invalid unicode escape sequence")
+	}
+	u, err := strconv.ParseUint(p.data[p.pos:p.pos+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(u)) {
+		return p.errorf(start, "invalid unicode escape sequence")
+	}
+
+	p.pos += size
+	b.WriteRune(rune(u))
+	return nil
+}
+
+// heredoc reads a heredoc (<<EOF) or an indented heredoc (<<-EOF).  The
+// indented form removes the leading whitespace common to all the lines.
+func (p *parser) heredoc() (string, error) {
+	start := p.pos
+	p.pos += 2
+	indented := p.consume("-")
+
+	marker, err := p.identifier()
+	if err != nil {
+		return "", err
+	}
+	if !p.consume("\n") && !p.consume("\r\n") {
+		return "", p.errorf(p.pos, "expected a newline after the heredoc marker")
+	}
+
+	var lines []string
+	for {
+		if p.eof() {
+			return "", p.errorf(start, "unterminated heredoc")
+		}
+
+		end := strings.IndexByte(p.data[p.pos:], '\n')
+		if end < 0 {
+			end = len(p.data) - p.pos
+		}
+		line := strings.TrimSuffix(p.data[p.pos:p.pos+end], "\r")
+
+		if strings.TrimSpace(line) == marker.text {
+			// Leave the newline for the end of the attribute.
+			p.pos += strings.Index(p.data[p.pos:], marker.text) + len(marker.text)
+			break
+		}
+
+		lines = append(lines, line)
+		p.pos = min(p.pos+end+1, len(p.data))
+	}
+
+	if indented {
+		lines = unindent(lines)
+	}
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String(), nil
+}
+
+// unindent removes the leading whitespace common to all the non-blank lines.
+func unindent(lines []string) []string {
+	common := -1
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := len(line) - len(strings.TrimLeft(line, " \t"))
+		if common < 0 || n < common {
+			common = n
+		}
+	}
+
+	rv := make([]string, len(lines))
+	for i, line := range lines {
+		rv[i] = line[min(max(common, 0), len(line)):]
+	}
+	return rv
+}
+
+// -- Whitespace, comments and positions ---------------------------------------
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	return p.data[p.pos]
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// consume advances past the string if it is next.
+func (p *parser) consume(s string) bool {
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// skip skips whitespace and comments.  Newlines are only skipped if
+// requested, except for those in block comments.
+func (p *parser) skip(newlines bool) error {
+	for !p.eof() {
+		rest := p.data[p.pos:]
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t':
+			p.pos++
+		case newlines && (rest[0] == '\n' || strings.HasPrefix(rest, "\r\n")):
+			p.pos++
+		case rest[0] == '#' || strings.HasPrefix(rest, "//"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			p.pos += len(strings.TrimSuffix(rest[:end], "\r"))
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return p.errorf(p.pos, "unterminated comment")
+			}
+			p.pos += end + 4
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// origin converts the offset into the line and column.  Both start at 1 and
+// the column is counted in characters.
+func (p *parser) origin(offset int) meta.Origin {
+	if p.lines == nil {
+		p.lines = []int{0}
+		for i := 0; i < len(p.data); i++ {
+			if p.data[i] == '\n' {
+				p.lines = append(p.lines, i+1)
+			}
+		}
+	}
+
+	offset = min(max(offset, 0), len(p.data))
+	line := sort.Search(len(p.lines), func(i int) bool {
+		return p.lines[i] > offset
+	}) - 1
+
+	return meta.Origin{
+		File: p.file,
+		Line: line + 1,
+		Col:  utf8.RuneCountInString(p.data[p.lines[line]:offset]) + 1,
+	}
+}
+
+func (p *parser) errorf(offset int, format string, a ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrSyntax, p.origin(offset), fmt.Sprintf(format, a...))
+}
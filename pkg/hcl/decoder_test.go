@@ -0,0 +1,360 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package hcl
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(line, col int) []meta.Origin {
+	return []meta.Origin{{File: "file.hcl", Line: line, Col: col}}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		expected    meta.Object
+		expectedErr string
+	}{
+		{
+			description: "an empty document",
+			in:          "# A comment.\n// Another.\n/* And\n another. */\n",
+			expected:    meta.Object{},
+		}, {
+			description: "attributes",
+			in: `name = "example \"quoted\" é" # A comment.
+port = 8080
+ratio = -0.5
+exp = 1e3
+on = true
+off = false
+none = null
+tmpl = "${HOME}/x and $${literal}"
+text = <<EOT
+line one
+  line two
+EOT
+indented = <<-EOT
+    a
+      b
+    EOT
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"name":     {Origins: at(1, 1), Value: `example "quoted" é`},
+					"port":     {Origins: at(2, 1), Value: int64(8080)},
+					"ratio":    {Origins: at(3, 1), Value: -0.5},
+					"exp":      {Origins: at(4, 1), Value: 1000.0},
+					"on":       {Origins: at(5, 1), Value: true},
+					"off":      {Origins: at(6, 1), Value: false},
+					"none":     {Origins: at(7, 1)},
+					"tmpl":     {Origins: at(8, 1), Value: "${HOME}/x and ${literal}"},
+					"text":     {Origins: at(9, 1), Value: "line one\n  line two\n"},
+					"indented": {Origins: at(13, 1), Value: "a\n  b\n"},
+				},
+			},
+		}, {
+			description: "tuples and objects",
+			in: `list = [1, "two",
+  [3], // A comment.
+]
+obj = {
+  a = 1, "b((secret))": "x"
+  c = {}
+}
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"list": {
+						Origins: at(1, 1),
+						Array: []meta.Object{
+							{Origins: at(1, 9), Value: int64(1)},
+							{Origins: at(1, 12), Value: "two"},
+							{
+								Origins: at(2, 3),
+								Array: []meta.Object{
+									{Origins: at(2, 4), Value: int64(3)},
+								},
+							},
+						},
+					},
+					"obj": {
+						Origins: at(4, 1),
+						Map: map[string]meta.Object{
+							"a":           {Origins: at(5, 3), Value: int64(1)},
+							"b((secret))": {Origins: at(5, 10), Value: "x"},
+							"c":           {Origins: at(6, 3), Map: map[string]meta.Object{}},
+						},
+					},
+				},
+			},
+		}, {
+			description: "blocks, labels and repeated blocks",
+			in: `server "web" "primary((secret))" {
+  port = 80
+  tls { on = true }
+}
+
+listener {
+  address = ":80"
+}
+listener {}
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"server": {
+						Origins: at(1, 1),
+						Map: map[string]meta.Object{
+							"web": {
+								Origins: at(1, 8),
+								Map: map[string]meta.Object{
+									"primary((secret))": {
+										Origins: at(1, 1),
+										Map: map[string]meta.Object{
+											"port": {Origins: at(2, 3), Value: int64(80)},
+											"tls": {
+												Origins: at(3, 3),
+												Map: map[string]meta.Object{
+													"on": {Origins: at(3, 9), Value: true},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					"listener": {
+						Origins: at(6, 1),
+						Array: []meta.Object{
+							{
+								Origins: at(6, 1),
+								Map: map[string]meta.Object{
+									"address": {Origins: at(7, 3), Value: ":80"},
+								},
+							},
+							{Origins: at(9, 1), Map: map[string]meta.Object{}},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a duplicate attribute",
+			in:          "a = 1\na = 2",
+			expectedErr: "file.hcl:2[1]: 'a' is already defined",
+		}, {
+			description: "a block and an attribute",
+			in:          "a = 1\na {}",
+			expectedErr: "file.hcl:2[3]: 'a' is already defined",
+		}, {
+			description: "blocks with and without labels",
+			in: `listener {
+  port = 80
+}
+listener "x" {
+  port = 81
+}
+listener "y" "z" {}
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"listener": {
+						Origins: at(1, 1),
+						Map: map[string]meta.Object{
+							"port": {Origins: at(2, 3), Value: int64(80)},
+							"x": {
+								Origins: at(4, 1),
+								Map: map[string]meta.Object{
+									"port": {Origins: at(5, 3), Value: int64(81)},
+								},
+							},
+							"y": {
+								Origins: at(7, 10),
+								Map: map[string]meta.Object{
+									"z": {Origins: at(7, 1), Map: map[string]meta.Object{}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a labelled block after a block without labels",
+			in:          "a \"x\" {}\na {}",
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"a": {
+						Origins: at(1, 1),
+						Map: map[string]meta.Object{
+							"x": {Origins: at(1, 1), Map: map[string]meta.Object{}},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a label with the same name as an attribute",
+			in:          "a {\n  x = 1\n}\na \"x\" {}",
+			expectedErr: "file.hcl:4[7]: 'a' blocks with and without labels conflict",
+		}, {
+			description: "several blocks without labels and a label",
+			in:          "a \"x\" {}\na {}\na {}",
+			expectedErr: "file.hcl:3[3]: 'a' blocks with and without labels conflict",
+		}, {
+			description: "a label and a nested block",
+			in:          "a {\n  x {}\n}\na \"x\" \"y\" {}",
+			expectedErr: "file.hcl:4[11]: 'a' blocks with and without labels conflict",
+		}, {
+			description: "labels of a label and a block",
+			in:          "a \"x\" { y = 1 }\na \"x\" \"y\" {}",
+			expectedErr: "file.hcl:2[11]: 'a.x' blocks with and without labels conflict",
+		}, {
+			description: "a duplicate object key",
+			in:          "a = { b = 1, b = 2 }",
+			expectedErr: "file.hcl:1[14]: 'b' is already defined",
+		}, {
+			description: "a variable",
+			in:          "a = var.name",
+			expectedErr: "unsupported expression 'var'",
+		}, {
+			description: "an operator",
+			in:          "a = 1 + 2",
+			expectedErr: "file.hcl:1[7]: expected a newline",
+		}, {
+			description: "a missing expression",
+			in:          "a = ",
+			expectedErr: "expected an expression",
+		}, {
+			description: "an invalid expression",
+			in:          "a = )",
+			expectedErr: "expected an expression",
+		}, {
+			description: "a missing equals or brace",
+			in:          "a \n",
+			expectedErr: "expected an identifier",
+		}, {
+			description: "an unterminated block",
+			in:          "a {\n b = 1\n",
+			expectedErr: "unterminated block",
+		}, {
+			description: "an unexpected brace",
+			in:          "}",
+			expectedErr: "unexpected '}'",
+		}, {
+			description: "an unterminated string",
+			in:          "a = \"abc\n",
+			expectedErr: "unterminated string",
+		}, {
+			description: "an invalid escape",
+			in:          `a = "\q"`,
+			expectedErr: `invalid escape sequence '\q'`,
+		}, {
+			description: "an unterminated template",
+			in:          `a = "${abc"`,
+			expectedErr: "unterminated template sequence",
+		}, {
+			description: "an unterminated tuple",
+			in:          "a = [1",
+			expectedErr: "unterminated tuple",
+		}, {
+			description: "an unterminated object",
+			in:          "a = {",
+			expectedErr: "unterminated object",
+		}, {
+			description: "an unterminated heredoc",
+			in:          "a = <<EOT\nabc\n",
+			expectedErr: "unterminated heredoc",
+		}, {
+			description: "an unterminated comment",
+			in:          "/* abc",
+			expectedErr: "unterminated comment",
+		}, {
+			description: "an invalid number",
+			in:          "a = -x",
+			expectedErr: "invalid number",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got meta.Object
+			ctx := decoder.Context{
+				Filename:  "file.hcl",
+				Delimiter: ".",
+			}
+			err := Decoder{}.Decode(ctx, []byte(tc.in), &got)
+
+			if tc.expectedErr != "" {
+				assert.ErrorIs(err, ErrSyntax)
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestDecoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"hcl"}, Decoder{}.Extensions())
+}
+
+func TestEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"app.hcl": &fstest.MapFile{
+			Data: []byte(`
+name = "example"
+backend "primary" {
+  port = 8080
+}
+listener { address = ":80" }
+listener { address = ":443" }
+`),
+		},
+	}
+
+	// The decoder is registered automatically.
+	gs, err := goschtalt.New(
+		goschtalt.AddDir(fs, "."),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	type listener struct {
+		Address string `goschtalt:"address"`
+	}
+	type backend struct {
+		Port int `goschtalt:"port"`
+	}
+	type config struct {
+		Name     string             `goschtalt:"name"`
+		Backend  map[string]backend `goschtalt:"backend"`
+		Listener []listener         `goschtalt:"listener"`
+	}
+
+	got, err := goschtalt.Unmarshal[config](gs, goschtalt.Root)
+	require.NoError(err)
+	assert.Equal(config{
+		Name:     "example",
+		Backend:  map[string]backend{"primary": {Port: 8080}},
+		Listener: []listener{{Address: ":80"}, {Address: ":443"}},
+	}, got)
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hcl provides an HCL decoder for goschtalt that only depends upon
+// the go standard library.
+//
+// The decoder supports the subset of the HCL native syntax (v2) used for
+// configuration: attributes, blocks, literal values, strings, heredocs,
+// tuples and objects.  Expressions that need to be evaluated (variables,
+// function calls and operators) are not supported.
+//
+// Blocks are mapped to maps, with each label adding another level of maps.
+// Blocks of the same type and labels that are repeated are mapped to an
+// array of maps.  For example:
+//
+//	server "web" {
+//	  port = 80
+//	}
+//
+//	listener {
+//	  address = ":80"
+//	}
+//
+//	listener {
+//	  address = ":443"
+//	}
+//
+// produces the same configuration tree as the following JSON:
+//
+//	{
+//	  "server": { "web": { "port": 80 } },
+//	  "listener": [ { "address": ":80" }, { "address": ":443" } ]
+//	}
+//
+// Since attribute names may not contain parentheses, the goschtalt key
+// instructions may be specified using the block labels or the keys of
+// objects:
+//
+//	database "primary((secret))" {
+//	  password = "hunter2"
+//	}
+//
+//	settings = {
+//	  "tags((append))" = [ "blue" ]
+//	}
+//
+// # Usage
+//
+// Add the following line to the import list & the decoder is automatically
+// registered for the 'hcl' extension.
+//
+//	import (
+//		_ "github.com/goschtalt/goschtalt/pkg/hcl"
+//	)
+//
+// Alternatively, the decoder may be registered explicitly.
+//
+//	gs, err := goschtalt.New(
+//		goschtalt.WithDecoder(hcl.Decoder{}),
+//	)
+package hcl
+
+import "github.com/goschtalt/goschtalt"
+
+func init() {
+	goschtalt.DefaultOptions = append(goschtalt.DefaultOptions,
+		goschtalt.WithDecoder(Decoder{}),
+	)
+}
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package ini
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ decoder.Decoder = (*Decoder)(nil)
+
+// ErrSyntax is returned when the document isn't a valid INI file.
+var ErrSyntax = errors.New("ini syntax error")
+
+// defaultDelimiter is used when the context doesn't specify a delimiter.
+const defaultDelimiter = "."
+
+// Decoder is an INI file decoder that records the origin of each key.
+//
+// The format supported is:
+//   - Sections are named in square brackets: [section]
+//   - Only a comment may follow the section name on the same line.
+//   - Keys and values are separated by '=' or ':': key = value
+//   - Lines starting with ';' or '#' are comments.
+//   - Values may be quoted with single or double quotes to preserve leading
+//     or trailing whitespace.  The quotes are removed.
+//   - Keys are not split using the delimiter, only the section names are.
+//
+// All values are strings since the format doesn't specify types.  The
+// adapters convert the values to the desired types when unmarshaling.
+type Decoder struct{}
+
+// Extensions returns the supported extensions.
+func (Decoder) Extensions() []string {
+	return []string{"ini", "conf"}
+}
+
+// Decode decodes an INI file into the meta.Object tree.  A document without
+// any sections or keys results in an empty tree.
+func (Decoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	delimiter := ctx.Delimiter
+	if delimiter == "" {
+		delimiter = defaultDelimiter
+	}
+
+	root := meta.Object{
+		Origins: []meta.Origin{{File: ctx.Filename, Line: 1, Col: 1}},
+		Map:     make(map[string]meta.Object),
+	}
+	section := root.Map
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t\r")
+		trimmed := strings.TrimLeft(text, " \t")
+		if trimmed == "" || trimmed[0] == ';' || trimmed[0] == '#' {
+			continue
+		}
+
+		// at provides the origin of the offset into the trimmed line.
+		indent := len(text) - len(trimmed)
+		at := func(offset int) meta.Origin {
+			return meta.Origin{
+				File: ctx.Filename,
+				Line: line,
+				Col:  utf8.RuneCountInString(text[:indent+offset]) + 1,
+			}
+		}
+
+		if trimmed[0] == '[' {
+			end := strings.IndexByte(trimmed, ']')
+			if end < 0 {
+				return fmt.Errorf("%w: %s: expected the end of the section name", ErrSyntax, at(0))
+			}
+
+			// Only a comment may follow the section name.
+			rest := strings.TrimLeft(trimmed[end+1:], " \t")
+			if rest != "" && rest[0] != ';' && rest[0] != '#' {
+				return fmt.Errorf("%w: %s: unexpected text after the section name",
+					ErrSyntax, at(len(trimmed)-len(rest)))
+			}
+
+			inner := trimmed[1:end]
+			name := strings.TrimSpace(inner)
+			if name == "" {
+				return fmt.Errorf("%w: %s: expected a section name", ErrSyntax, at(0))
+			}
+			origin := at(1 + len(inner) - len(strings.TrimLeft(inner, " \t")))
+
+			var err error
+			section, err = sectionMap(root.Map, name, delimiter, origin)
+			if err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrSyntax, origin, err)
+			}
+			continue
+		}
+
+		i := strings.IndexAny(trimmed, "=:")
+		if i < 0 {
+			return fmt.Errorf("%w: %s: expected '=' or ':' after the key", ErrSyntax, at(0))
+		}
+
+		key := strings.TrimSpace(trimmed[:i])
+		if key == "" {
+			return fmt.Errorf("%w: %s: expected a key", ErrSyntax, at(0))
+		}
+
+		val := meta.Object{
+			Origins: []meta.Origin{at(0)},
+			Value:   unquote(strings.TrimSpace(trimmed[i+1:])),
+		}
+
+		if err := addValue(section, key, val); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrSyntax, val.Origins[0], err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(root.Map) == 0 {
+		*m = meta.Object{}
+		return nil
+	}
+
+	*m = root
+	return nil
+}
+
+// sectionMap returns the map for the section, creating the maps needed.
+func sectionMap(m map[string]meta.Object, section, delimiter string, origin meta.Origin) (map[string]meta.Object, error) {
+	names := strings.Split(section, delimiter)
+	for i, name := range names {
+		child, found := m[name]
+		if !found {
+			child = meta.Object{
+				Origins: []meta.Origin{origin},
+				Map:     make(map[string]meta.Object),
+			}
+			m[name] = child
+		}
+
+		if child.Map == nil {
+			return nil, fmt.Errorf("section '%s' is already defined as a key",
+				strings.Join(names[:i+1], delimiter))
+		}
+		m = child.Map
+	}
+
+	return m, nil
+}
+
+// addValue adds the value to the section.  Repeated keys produce an array of
+// the values.
+func addValue(m map[string]meta.Object, key string, val meta.Object) error {
+	existing, found := m[key]
+	switch {
+	case !found:
+		m[key] = val
+	case existing.Map != nil:
+		return fmt.Errorf("key '%s' is already defined as a section", key)
+	case existing.Array != nil:
+		existing.Array = append(existing.Array, val)
+		m[key] = existing
+	default:
+		m[key] = meta.Object{
+			Origins: existing.Origins,
+			Array:   []meta.Object{existing, val},
+		}
+	}
+
+	return nil
+}
+
+// unquote removes matching quotes surrounding the value.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
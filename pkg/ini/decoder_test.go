@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package ini
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(line, col int) []meta.Origin {
+	return []meta.Origin{{File: "file.ini", Line: line, Col: col}}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		delimiter   string
+		expected    meta.Object
+		expectedErr string
+	}{
+		{
+			description: "an empty document",
+			in:          "; Only a comment.\n# Another.\n\n",
+			expected:    meta.Object{},
+		}, {
+			description: "keys, sections and repeated keys",
+			in: `name = example
+  ; An indented comment.
+[server.tls]
+  cipher: strong
+[ server ]
+listen = :80
+listen = ":443 "
+listen = ':8080'
+"quote = a "b" c
+[db((secret))] ; The credentials.
+password=hunter2
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"name": {Origins: at(1, 1), Value: "example"},
+					"server": {
+						Origins: at(3, 2),
+						Map: map[string]meta.Object{
+							"tls": {
+								Origins: at(3, 2),
+								Map: map[string]meta.Object{
+									"cipher": {Origins: at(4, 3), Value: "strong"},
+								},
+							},
+							"listen": {
+								Origins: at(6, 1),
+								Array: []meta.Object{
+									{Origins: at(6, 1), Value: ":80"},
+									{Origins: at(7, 1), Value: ":443 "},
+									{Origins: at(8, 1), Value: ":8080"},
+								},
+							},
+							`"quote`: {Origins: at(9, 1), Value: `a "b" c`},
+						},
+					},
+					"db((secret))": {
+						Origins: at(10, 2),
+						Map: map[string]meta.Object{
+							"password": {Origins: at(11, 1), Value: "hunter2"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "a different delimiter",
+			in:          "[a/b]\nc = d\n",
+			delimiter:   "/",
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"a": {
+						Origins: at(1, 2),
+						Map: map[string]meta.Object{
+							"b": {
+								Origins: at(1, 2),
+								Map: map[string]meta.Object{
+									"c": {Origins: at(2, 1), Value: "d"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "columns are counted in characters",
+			in:          "[ü]\n\tkey = value",
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"ü": {
+						Origins: at(1, 2),
+						Map: map[string]meta.Object{
+							"key": {Origins: at(2, 2), Value: "value"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "an unterminated section",
+			in:          "[a",
+			expectedErr: "file.ini:1[1]: expected the end of the section name",
+		}, {
+			description: "a section with an extra bracket",
+			in:          "[s]]",
+			expectedErr: "file.ini:1[4]: unexpected text after the section name",
+		}, {
+			description: "a section with text after it",
+			in:          "key = value\n  [s] other",
+			expectedErr: "file.ini:2[7]: unexpected text after the section name",
+		}, {
+			description: "an empty section",
+			in:          "[ ]",
+			expectedErr: "file.ini:1[1]: expected a section name",
+		}, {
+			description: "a missing separator",
+			in:          "\n  key",
+			expectedErr: "file.ini:2[3]: expected '=' or ':' after the key",
+		}, {
+			description: "a missing key",
+			in:          "= value",
+			expectedErr: "file.ini:1[1]: expected a key",
+		}, {
+			description: "a section that is a key",
+			in:          "a = 1\n[a.b]",
+			expectedErr: "file.ini:2[2]: section 'a' is already defined as a key",
+		}, {
+			description: "a key that is a section",
+			in:          "[a.b]\n[a]\nb = 1",
+			expectedErr: "file.ini:3[1]: key 'b' is already defined as a section",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got meta.Object
+			ctx := decoder.Context{
+				Filename:  "file.ini",
+				Delimiter: tc.delimiter,
+			}
+			err := Decoder{}.Decode(ctx, []byte(tc.in), &got)
+
+			if tc.expectedErr != "" {
+				assert.ErrorIs(err, ErrSyntax)
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestDecoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"ini", "conf"}, Decoder{}.Extensions())
+}
+
+func TestEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"app.conf": &fstest.MapFile{
+			Data: []byte("[server]\nport = 8080\nlisten = a\nlisten = b\n"),
+		},
+	}
+
+	// The decoder is registered automatically.
+	gs, err := goschtalt.New(
+		goschtalt.AddDir(fs, "."),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		Port   string   `goschtalt:"port"`
+		Listen []string `goschtalt:"listen"`
+	}
+
+	got, err := goschtalt.Unmarshal[config](gs, "server")
+	require.NoError(err)
+	assert.Equal(config{Port: "8080", Listen: []string{"a", "b"}}, got)
+}
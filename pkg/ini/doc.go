@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ini provides an INI file decoder for goschtalt that only depends
+// upon the go standard library.
+//
+// Sections are mapped to maps and the section names are split into nested
+// maps using the key delimiter, so the following two examples produce the same
+// configuration tree:
+//
+//	[server.tls]
+//	cipher = strong
+//
+//	[server]
+//	[server.tls]
+//	cipher = strong
+//
+// Keys that are repeated in a section produce an array of the values in the
+// order they are found:
+//
+//	[server]
+//	listen = :80
+//	listen = :443
+//
+// # Usage
+//
+// Add the following line to the import list & the decoder is automatically
+// registered for the 'ini' and 'conf' extensions.
+//
+//	import (
+//		_ "github.com/goschtalt/goschtalt/pkg/ini"
+//	)
+//
+// Alternatively, the decoder may be registered explicitly.
+//
+//	gs, err := goschtalt.New(
+//		goschtalt.WithDecoder(ini.Decoder{}),
+//	)
+package ini
+
+import "github.com/goschtalt/goschtalt"
+
+func init() {
+	goschtalt.DefaultOptions = append(goschtalt.DefaultOptions,
+		goschtalt.WithDecoder(Decoder{}),
+	)
+}
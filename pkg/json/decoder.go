@@ -0,0 +1,243 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ decoder.Decoder = (*Decoder)(nil)
+
+// ErrSyntax is returned when the document isn't valid JSON or isn't an object.
+var ErrSyntax = errors.New("json syntax error")
+
+// Decoder is a JSON decoder that records the origin of each value.
+//
+// The origin of a member of an object is the position of its key, so the
+// line reported is the one the key is on.  The origin of all other values is
+// the position where the value starts.
+type Decoder struct{}
+
+// Extensions returns the supported extensions.
+func (Decoder) Extensions() []string {
+	return []string{"json"}
+}
+
+// Decode decodes a JSON document into the meta.Object tree.  Numbers are
+// decoded as int64 values if possible, then uint64 values, then float64
+// values.  An empty document results in an empty tree, otherwise the document
+// must be an object.
+func (Decoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	if len(bytes.TrimSpace(b)) == 0 {
+		*m = meta.Object{}
+		return nil
+	}
+
+	p := parser{
+		file: ctx.Filename,
+		data: b,
+		dec:  json.NewDecoder(bytes.NewReader(b)),
+	}
+	p.dec.UseNumber()
+
+	tok, at, err := p.next()
+	if err != nil {
+		return err
+	}
+
+	if tok != json.Delim('{') {
+		return fmt.Errorf("%w: %s: the document must be an object", ErrSyntax, at)
+	}
+
+	obj, err := p.value(tok, at)
+	if err != nil {
+		return err
+	}
+
+	// Only a single document is allowed.
+	offset := p.start(p.dec.InputOffset())
+	if _, err = p.dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: %s: unexpected data after the document", ErrSyntax, p.origin(offset))
+	}
+
+	*m = obj
+	return nil
+}
+
+// parser walks the tokens of a JSON document and builds the tree.
+type parser struct {
+	file  string
+	data  []byte
+	dec   *json.Decoder
+	lines []int
+}
+
+// next returns the next token and where it started.
+func (p *parser) next() (json.Token, meta.Origin, error) {
+	offset := p.start(p.dec.InputOffset())
+
+	tok, err := p.dec.Token()
+	if err != nil {
+		var se *json.SyntaxError
+		if errors.As(err, &se) {
+			// The offset is just past the offending character.
+			offset = se.Offset - 1
+		}
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, meta.Origin{}, fmt.Errorf("%w: %s: %w", ErrSyntax, p.origin(offset), err)
+	}
+
+	return tok, p.origin(offset), nil
+}
+
+// value converts the token (and any tokens that are part of it) into an object.
+func (p *parser) value(tok json.Token, at meta.Origin) (meta.Object, error) {
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '{' {
+			return p.object(at)
+		}
+		return p.array(at)
+	case json.Number:
+		return meta.Object{
+			Origins: []meta.Origin{at},
+			Value:   number(t),
+		}, nil
+	}
+
+	// Only strings, booleans and null remain.
+	return meta.Object{
+		Origins: []meta.Origin{at},
+		Value:   tok,
+	}, nil
+}
+
+func (p *parser) object(at meta.Origin) (meta.Object, error) {
+	obj := meta.Object{
+		Origins: []meta.Origin{at},
+		Map:     make(map[string]meta.Object),
+	}
+
+	for p.dec.More() {
+		tok, keyAt, err := p.next()
+		if err != nil {
+			return meta.Object{}, err
+		}
+		key := tok.(string)
+
+		tok, valAt, err := p.next()
+		if err != nil {
+			return meta.Object{}, err
+		}
+
+		val, err := p.value(tok, valAt)
+		if err != nil {
+			return meta.Object{}, err
+		}
+
+		val.Origins = []meta.Origin{keyAt}
+		obj.Map[key] = val
+	}
+
+	// Consume the closing delimiter.
+	if _, _, err := p.next(); err != nil {
+		return meta.Object{}, err
+	}
+
+	return obj, nil
+}
+
+func (p *parser) array(at meta.Origin) (meta.Object, error) {
+	obj := meta.Object{
+		Origins: []meta.Origin{at},
+		Array:   []meta.Object{},
+	}
+
+	for p.dec.More() {
+		tok, valAt, err := p.next()
+		if err != nil {
+			return meta.Object{}, err
+		}
+
+		val, err := p.value(tok, valAt)
+		if err != nil {
+			return meta.Object{}, err
+		}
+
+		obj.Array = append(obj.Array, val)
+	}
+
+	// Consume the closing delimiter.
+	if _, _, err := p.next(); err != nil {
+		return meta.Object{}, err
+	}
+
+	return obj, nil
+}
+
+// start returns the offset of the next token, skipping the whitespace and
+// separators the json.Decoder consumes as part of the next token.
+func (p *parser) start(offset int64) int64 {
+	for offset < int64(len(p.data)) {
+		switch p.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+			continue
+		}
+		break
+	}
+	return offset
+}
+
+// origin converts the offset into the line and column.  Both start at 1 and
+// the column is counted in characters.
+func (p *parser) origin(offset int64) meta.Origin {
+	if p.lines == nil {
+		p.lines = []int{0}
+		for i, c := range p.data {
+			if c == '\n' {
+				p.lines = append(p.lines, i+1)
+			}
+		}
+	}
+
+	offset = min(max(offset, 0), int64(len(p.data)))
+	line := sort.Search(len(p.lines), func(i int) bool {
+		return int64(p.lines[i]) > offset
+	}) - 1
+
+	return meta.Origin{
+		File: p.file,
+		Line: line + 1,
+		Col:  utf8.RuneCount(p.data[p.lines[line]:offset]) + 1,
+	}
+}
+
+// number converts the number into the best fitting type.
+func number(n json.Number) any {
+	if i, err := n.Int64(); err == nil {
+		return i
+	}
+	if u, err := strconv.ParseUint(n.String(), 10, 64); err == nil {
+		return u
+	}
+	if f, err := n.Float64(); err == nil {
+		return f
+	}
+
+	// The number is too large for any type, so leave it as a string.
+	return n.String()
+}
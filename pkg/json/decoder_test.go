@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(line, col int) []meta.Origin {
+	return []meta.Origin{{File: "file.json", Line: line, Col: col}}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		expected    meta.Object
+		expectedErr string
+	}{
+		{
+			description: "an empty document",
+			in:          " \n ",
+			expected:    meta.Object{},
+		}, {
+			description: "a simple document",
+			in: `{
+  "name": "example",
+  "port": 8080,
+  "ratio": 0.5,
+  "big": 18446744073709551615,
+  "on": true,
+  "none": null
+}`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"name":  {Origins: at(2, 3), Value: "example"},
+					"port":  {Origins: at(3, 3), Value: int64(8080)},
+					"ratio": {Origins: at(4, 3), Value: 0.5},
+					"big":   {Origins: at(5, 3), Value: uint64(18446744073709551615)},
+					"on":    {Origins: at(6, 3), Value: true},
+					"none":  {Origins: at(7, 3)},
+				},
+			},
+		}, {
+			description: "nested maps and arrays",
+			in: `{"a": {"b((secret))": [1,
+   "two", {"x": []}, {}]}}`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"a": {
+						Origins: at(1, 2),
+						Map: map[string]meta.Object{
+							"b((secret))": {
+								Origins: at(1, 8),
+								Array: []meta.Object{
+									{Origins: at(1, 24), Value: int64(1)},
+									{Origins: at(2, 4), Value: "two"},
+									{
+										Origins: at(2, 11),
+										Map: map[string]meta.Object{
+											"x": {Origins: at(2, 12), Array: []meta.Object{}},
+										},
+									},
+									{Origins: at(2, 22), Map: map[string]meta.Object{}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "columns are counted in characters",
+			in:          `{"ü": "ö", "k": 1}`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"ü": {Origins: at(1, 2), Value: "ö"},
+					"k": {Origins: at(1, 12), Value: int64(1)},
+				},
+			},
+		}, {
+			description: "a value as the document",
+			in:          `  "text"`,
+			expectedErr: "file.json:1[3]: the document must be an object",
+		}, {
+			description: "an array as the document",
+			in:          `[{"a": 1}]`,
+			expectedErr: "file.json:1[1]: the document must be an object",
+		}, {
+			description: "invalid document",
+			in:          "{\n  \"a\": tru\n}",
+			expectedErr: "file.json:2[",
+		}, {
+			description: "truncated document",
+			in:          `{"a": [1, 2`,
+			expectedErr: "file.json:1[11]: unexpected end of JSON input",
+		}, {
+			description: "extra data",
+			in:          `{"a": 1} {"b": 2}`,
+			expectedErr: "file.json:1[10]: unexpected data after the document",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got meta.Object
+			ctx := decoder.Context{
+				Filename:  "file.json",
+				Delimiter: ".",
+			}
+			err := Decoder{}.Decode(ctx, []byte(tc.in), &got)
+
+			if tc.expectedErr != "" {
+				assert.ErrorIs(err, ErrSyntax)
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestDecoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"json"}, Decoder{}.Extensions())
+}
+
+func TestEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"1.json": &fstest.MapFile{
+			Data: []byte(`{"name": "one", "port": 80}`),
+		},
+		"2.json": &fstest.MapFile{
+			Data: []byte("{\n  \"port\": 8080\n}"),
+		},
+	}
+
+	// The decoder and encoder are registered automatically.
+	gs, err := goschtalt.New(
+		goschtalt.AddDir(fs, "."),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		Name string `goschtalt:"name"`
+		Port int    `goschtalt:"port"`
+	}
+
+	got, err := goschtalt.Unmarshal[config](gs, goschtalt.Root)
+	require.NoError(err)
+	assert.Equal(config{Name: "one", Port: 8080}, got)
+
+	out, err := gs.Marshal(goschtalt.FormatAs("json"), goschtalt.IncludeOrigins())
+	require.NoError(err)
+	assert.Contains(string(out), `"/port": [`+"\n"+`      "2.json:2[3]"`)
+}
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package json provides a JSON decoder and encoder for goschtalt that only
+// depend upon the go standard library.
+//
+// The decoder records the filename, line and column of every value it decodes
+// so the origins of the configuration are available when explaining the
+// configuration or reporting errors.
+//
+// The encoder produces the JSON document for normal output.  When the origins
+// are requested it produces a document with the configuration under the
+// 'config' key and the origins of each value under the 'origins' key, indexed
+// by the JSON Pointer (RFC 6901) of the value.
+//
+// # Usage
+//
+// Add the following line to the import list & the decoder and encoder are
+// automatically registered for the 'json' extension.
+//
+//	import (
+//		_ "github.com/goschtalt/goschtalt/pkg/json"
+//	)
+//
+// Alternatively, the decoder and encoder may be registered explicitly.
+//
+//	gs, err := goschtalt.New(
+//		goschtalt.WithDecoder(json.Decoder{}),
+//		goschtalt.WithEncoder(json.Encoder{}),
+//	)
+package json
+
+import "github.com/goschtalt/goschtalt"
+
+func init() {
+	goschtalt.DefaultOptions = append(goschtalt.DefaultOptions,
+		goschtalt.WithDecoder(Decoder{}),
+		goschtalt.WithEncoder(Encoder{}),
+	)
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/pkg/encoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ encoder.Encoder = (*Encoder)(nil)
+
+// Encoder is a JSON encoder.
+type Encoder struct{}
+
+// Extensions returns the supported extensions.
+func (Encoder) Extensions() []string {
+	return []string{"json"}
+}
+
+// Encode encodes the value as an indented JSON document.
+func (Encoder) Encode(v any) ([]byte, error) {
+	return marshal(v)
+}
+
+// extended is the document produced by EncodeExtended.
+type extended struct {
+	Config  any                 `json:"config"`
+	Origins map[string][]string `json:"origins,omitempty"`
+}
+
+// EncodeExtended encodes the configuration along with the origins of the
+// values.  Since JSON doesn't support comments the origins are placed in a
+// sidecar structure.  The document has the form:
+//
+//	{
+//	  "config": {
+//	    "name": "example"
+//	  },
+//	  "origins": {
+//	    "": [ "file.json:1[1]" ],
+//	    "/name": [ "file.json:2[3]" ]
+//	  }
+//	}
+//
+// The origins are indexed by the JSON Pointer (RFC 6901) of the value they
+// describe, with the empty string referring to the entire configuration.
+func (Encoder) EncodeExtended(obj meta.Object) ([]byte, error) {
+	doc := extended{
+		Config:  obj.ToRaw(),
+		Origins: make(map[string][]string),
+	}
+
+	addOrigins(doc.Origins, "", obj)
+
+	return marshal(doc)
+}
+
+// addOrigins adds the origins of the object and its children to the map.
+func addOrigins(m map[string][]string, pointer string, obj meta.Object) {
+	if len(obj.Origins) > 0 {
+		list := make([]string, len(obj.Origins))
+		for i, origin := range obj.Origins {
+			list[i] = origin.String()
+		}
+		m[pointer] = list
+	}
+
+	switch obj.Kind() {
+	case meta.Array:
+		for i, val := range obj.Array {
+			addOrigins(m, pointer+"/"+strconv.Itoa(i), val)
+		}
+	case meta.Map:
+		for key, val := range obj.Map {
+			addOrigins(m, pointer+"/"+escaper.Replace(key), val)
+		}
+	}
+}
+
+// escaper escapes the reference tokens of a JSON Pointer.
+var escaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+func marshal(v any) ([]byte, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package json
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          any
+		expected    string
+		expectErr   bool
+	}{
+		{
+			description: "a simple map",
+			in: map[string]any{
+				"name": "example",
+				"list": []any{1, "two"},
+			},
+			expected: `{
+  "list": [
+    1,
+    "two"
+  ],
+  "name": "example"
+}
+`,
+		}, {
+			description: "an unsupported value",
+			in:          map[string]any{"f": func() {}},
+			expectErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Encoder{}.Encode(tc.in)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+}
+
+func TestEncodeExtended(t *testing.T) {
+	tests := []struct {
+		description string
+		in          meta.Object
+		expected    string
+		expectErr   bool
+	}{
+		{
+			description: "an empty tree",
+			in:          meta.Object{},
+			expected: `{
+  "config": null
+}
+`,
+		}, {
+			description: "a tree with origins",
+			in: meta.Object{
+				Origins: []meta.Origin{{File: "a.json", Line: 1, Col: 1}},
+				Map: map[string]meta.Object{
+					"a/b~c": {
+						Origins: []meta.Origin{
+							{File: "a.json", Line: 2, Col: 3},
+							{File: "b.json", Line: 4, Col: 5},
+						},
+						Value: "x",
+					},
+					"list": {
+						Array: []meta.Object{
+							{Origins: []meta.Origin{{File: "c.json"}}, Value: int64(1)},
+						},
+					},
+				},
+			},
+			expected: `{
+  "config": {
+    "a/b~c": "x",
+    "list": [
+      1
+    ]
+  },
+  "origins": {
+    "": [
+      "a.json:1[1]"
+    ],
+    "/a~1b~0c": [
+      "a.json:2[3]",
+      "b.json:4[5]"
+    ],
+    "/list/0": [
+      "c.json"
+    ]
+  }
+}
+`,
+		}, {
+			description: "an unsupported value",
+			in:          meta.Object{Value: func() {}},
+			expectErr:   true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Encoder{}.EncodeExtended(tc.in)
+
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+}
+
+func TestEncoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"json"}, Encoder{}.Extensions())
+}
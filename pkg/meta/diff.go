@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/goschtalt/goschtalt/internal/natsort"
+	"github.com/goschtalt/goschtalt/internal/strs"
+)
+
+// Difference describes a single value that differs between two trees.
+type Difference struct {
+	Path       []string // The path to the value.
+	OldOrigins []Origin // The origins of the value in the original tree.
+	NewOrigins []Origin // The origins of the value in the other tree.
+}
+
+// Differences describes all the values that differ between two trees.  Each
+// list is sorted by path.
+type Differences struct {
+	Added   []Difference // Values only present in the other tree.
+	Removed []Difference // Values only present in the original tree.
+	Changed []Difference // Values present in both trees, but different.
+}
+
+// IsEmpty returns if there are no differences.
+func (d Differences) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Paths returns the sorted list of all the paths that are different.
+func (d Differences) Paths() [][]string {
+	paths := make([][]string, 0, len(d.Added)+len(d.Removed)+len(d.Changed))
+	for _, list := range [][]Difference{d.Added, d.Removed, d.Changed} {
+		for _, item := range list {
+			paths = append(paths, item.Path)
+		}
+	}
+	sort.SliceStable(paths, func(i, j int) bool {
+		return pathLess(paths[i], paths[j])
+	})
+	return paths
+}
+
+// Diff compares the values in the tree with the values in the other tree and
+// returns the differences.  Differences are reported for the values (the leaves
+// of the trees) only; the root of the tree is never reported.  If a map or
+// array is replaced by a value (or the opposite), the old values are reported
+// as removed and the new values are reported as added.
+func (obj Object) Diff(other Object) Differences {
+	var d Differences
+	obj.diff(other, nil, &d)
+
+	for _, list := range [][]Difference{d.Added, d.Removed, d.Changed} {
+		sort.SliceStable(list, func(i, j int) bool {
+			return pathLess(list[i].Path, list[j].Path)
+		})
+	}
+	return d
+}
+
+func (obj Object) diff(other Object, path []string, d *Differences) {
+	objKind, otherKind := obj.Kind(), other.Kind()
+	if objKind != otherKind {
+		obj.leaves(path, func(p []string, leaf Object) {
+			d.Removed = append(d.Removed, Difference{Path: p, OldOrigins: leaf.Origins})
+		})
+		other.leaves(path, func(p []string, leaf Object) {
+			d.Added = append(d.Added, Difference{Path: p, NewOrigins: leaf.Origins})
+		})
+		return
+	}
+
+	switch objKind {
+	case Map:
+		for key, val := range obj.Map {
+			if next, found := other.Map[key]; found {
+				val.diff(next, strs.Append(path, key), d)
+				continue
+			}
+			val.leaves(strs.Append(path, key), func(p []string, leaf Object) {
+				d.Removed = append(d.Removed, Difference{Path: p, OldOrigins: leaf.Origins})
+			})
+		}
+		for key, val := range other.Map {
+			if _, found := obj.Map[key]; found {
+				continue
+			}
+			val.leaves(strs.Append(path, key), func(p []string, leaf Object) {
+				d.Added = append(d.Added, Difference{Path: p, NewOrigins: leaf.Origins})
+			})
+		}
+	case Array:
+		for i := 0; i < len(obj.Array) || i < len(other.Array); i++ {
+			p := strs.Append(path, strconv.Itoa(i))
+			switch {
+			case i >= len(other.Array):
+				obj.Array[i].leaves(p, func(p []string, leaf Object) {
+					d.Removed = append(d.Removed, Difference{Path: p, OldOrigins: leaf.Origins})
+				})
+			case i >= len(obj.Array):
+				other.Array[i].leaves(p, func(p []string, leaf Object) {
+					d.Added = append(d.Added, Difference{Path: p, NewOrigins: leaf.Origins})
+				})
+			default:
+				obj.Array[i].diff(other.Array[i], p, d)
+			}
+		}
+	default:
+		if len(path) > 0 && !reflect.DeepEqual(obj.Value, other.Value) {
+			d.Changed = append(d.Changed, Difference{
+				Path:       path,
+				OldOrigins: obj.Origins,
+				NewOrigins: other.Origins,
+			})
+		}
+	}
+}
+
+// leaves calls fn with the path of each value in the tree.  The root of the
+// tree is not reported.
+func (obj Object) leaves(path []string, fn func([]string, Object)) {
+	switch obj.Kind() {
+	case Map:
+		for key, val := range obj.Map {
+			val.leaves(strs.Append(path, key), fn)
+		}
+	case Array:
+		for i, val := range obj.Array {
+			val.leaves(strs.Append(path, strconv.Itoa(i)), fn)
+		}
+	default:
+		if len(path) > 0 {
+			fn(path, obj)
+		}
+	}
+}
+
+// pathLess orders paths element by element using a natural sort so array
+// indexes are ordered numerically.
+func pathLess(a, b []string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return natsort.Compare(a[i], b[i])
+		}
+	}
+	return len(a) < len(b)
+}
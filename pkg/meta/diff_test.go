@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	one := []Origin{{File: "one.yml", Line: 1}}
+	two := []Origin{{File: "two.yml", Line: 2}}
+
+	tests := []struct {
+		description string
+		a, b        Object
+		expected    Differences
+		paths       [][]string
+	}{
+		{
+			description: "empty",
+		}, {
+			description: "identical, different origins",
+			a:           ObjectFromRawWithOrigin(map[string]any{"a": "b"}, one),
+			b:           ObjectFromRawWithOrigin(map[string]any{"a": "b"}, two),
+		}, {
+			description: "from nothing",
+			b:           ObjectFromRawWithOrigin(map[string]any{"a": "b"}, two),
+			expected: Differences{
+				Added: []Difference{{Path: []string{"a"}, NewOrigins: two}},
+			},
+			paths: [][]string{{"a"}},
+		}, {
+			description: "to nothing",
+			a:           ObjectFromRawWithOrigin(map[string]any{"a": "b"}, one),
+			expected: Differences{
+				Removed: []Difference{{Path: []string{"a"}, OldOrigins: one}},
+			},
+			paths: [][]string{{"a"}},
+		}, {
+			description: "all the changes",
+			a: ObjectFromRawWithOrigin(map[string]any{
+				"same":    "same",
+				"removed": "gone",
+				"changed": "before",
+				"kind":    map[string]any{"a": "b"},
+				"list":    []any{"a", "b", "c"},
+			}, one),
+			b: ObjectFromRawWithOrigin(map[string]any{
+				"same":    "same",
+				"added":   "new",
+				"changed": "after",
+				"kind":    "value",
+				"list":    []any{"a", "x"},
+			}, two),
+			expected: Differences{
+				Added: []Difference{
+					{Path: []string{"added"}, NewOrigins: two},
+					{Path: []string{"kind"}, NewOrigins: two},
+				},
+				Removed: []Difference{
+					{Path: []string{"kind", "a"}, OldOrigins: one},
+					{Path: []string{"list", "2"}, OldOrigins: one},
+					{Path: []string{"removed"}, OldOrigins: one},
+				},
+				Changed: []Difference{
+					{Path: []string{"changed"}, OldOrigins: one, NewOrigins: two},
+					{Path: []string{"list", "1"}, OldOrigins: one, NewOrigins: two},
+				},
+			},
+			paths: [][]string{
+				{"added"},
+				{"changed"},
+				{"kind"},
+				{"kind", "a"},
+				{"list", "1"},
+				{"list", "2"},
+				{"removed"},
+			},
+		}, {
+			description: "array indexes sort numerically",
+			b:           ObjectFromRawWithOrigin([]any{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, two),
+			expected: Differences{
+				Added: []Difference{
+					{Path: []string{"0"}, NewOrigins: two},
+					{Path: []string{"1"}, NewOrigins: two},
+					{Path: []string{"2"}, NewOrigins: two},
+					{Path: []string{"3"}, NewOrigins: two},
+					{Path: []string{"4"}, NewOrigins: two},
+					{Path: []string{"5"}, NewOrigins: two},
+					{Path: []string{"6"}, NewOrigins: two},
+					{Path: []string{"7"}, NewOrigins: two},
+					{Path: []string{"8"}, NewOrigins: two},
+					{Path: []string{"9"}, NewOrigins: two},
+					{Path: []string{"10"}, NewOrigins: two},
+				},
+			},
+			paths: [][]string{{"0"}, {"1"}, {"2"}, {"3"}, {"4"}, {"5"}, {"6"}, {"7"}, {"8"}, {"9"}, {"10"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := tc.a.Diff(tc.b)
+			assert.Equal(tc.expected, got)
+			assert.Equal(len(tc.paths) == 0, got.IsEmpty())
+			if len(tc.paths) == 0 {
+				assert.Empty(got.Paths())
+				return
+			}
+			assert.Equal(tc.paths, got.Paths())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"sort"
+)
+
+// Hash returns a hash of the tree starting at this Object.  Only the shape of
+// the tree, the values and if the values are secret are included; the origins
+// are ignored.  Two trees that unmarshal to the same result produce the same
+// hash regardless of where the values came from.
+func (obj Object) Hash() []byte {
+	h := sha256.New()
+	obj.hash(h)
+	return h.Sum(nil)
+}
+
+func (obj Object) hash(h hash.Hash) {
+	if obj.secret {
+		fmt.Fprint(h, "s")
+	}
+
+	switch obj.Kind() {
+	case Array:
+		fmt.Fprintf(h, "a%d:", len(obj.Array))
+		for _, val := range obj.Array {
+			val.hash(h)
+		}
+	case Map:
+		keys := make([]string, 0, len(obj.Map))
+		for key := range obj.Map {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fmt.Fprintf(h, "m%d:", len(keys))
+		for _, key := range keys {
+			fmt.Fprintf(h, "%d:%s", len(key), key)
+			obj.Map[key].hash(h)
+		}
+	default:
+		val := fmt.Sprintf("%T:%v", obj.Value, obj.Value)
+		fmt.Fprintf(h, "v%d:%s", len(val), val)
+	}
+}
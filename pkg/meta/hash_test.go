@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        Object
+		same        bool
+	}{
+		{
+			description: "empty objects",
+			same:        true,
+		}, {
+			description: "same map, different origins",
+			a:           ObjectFromRawWithOrigin(decode(`{"a":{"b":"c"}}`).ToRaw(), []Origin{{File: "one"}}),
+			b:           ObjectFromRawWithOrigin(decode(`{"a":{"b":"c"}}`).ToRaw(), []Origin{{File: "two"}}),
+			same:        true,
+		}, {
+			description: "same array",
+			a:           decode(`[1, 2, "three"]`),
+			b:           decode(`[1, 2, "three"]`),
+			same:        true,
+		}, {
+			description: "different values",
+			a:           decode(`{"a":"b"}`),
+			b:           decode(`{"a":"c"}`),
+		}, {
+			description: "different types",
+			a:           ObjectFromRaw(map[string]any{"a": 1}),
+			b:           ObjectFromRaw(map[string]any{"a": "1"}),
+		}, {
+			description: "different keys",
+			a:           decode(`{"a":"b"}`),
+			b:           decode(`{"b":"b"}`),
+		}, {
+			description: "keys that run together",
+			a:           decode(`{"ab":"c", "d":"e"}`),
+			b:           decode(`{"a":"bc", "d":"e"}`),
+		}, {
+			description: "array order",
+			a:           decode(`[1, 2]`),
+			b:           decode(`[2, 1]`),
+		}, {
+			description: "array vs map",
+			a:           decode(`["a"]`),
+			b:           decode(`{"0":"a"}`),
+		}, {
+			description: "secret vs not",
+			a:           decode(`{"a((secret))":"b"}`),
+			b:           decode(`{"a":"b"}`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			a, b := tc.a, tc.b
+			if resolved, err := a.ResolveCommands(); err == nil {
+				a = resolved
+			}
+			if resolved, err := b.ResolveCommands(); err == nil {
+				b = resolved
+			}
+
+			assert.Equal(a.Hash(), a.Hash())
+			if tc.same {
+				assert.Equal(a.Hash(), b.Hash())
+				return
+			}
+			assert.NotEqual(a.Hash(), b.Hash())
+		})
+	}
+}
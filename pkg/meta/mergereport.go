@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package meta
+
+import "github.com/goschtalt/goschtalt/internal/strs"
+
+// The actions reported in a MergeEvent.
+const (
+	ActionSet     = "set"     // The key was not present and has been set.
+	ActionReplace = "replace" // The existing value was replaced.
+	ActionAppend  = "append"  // The new array was appended to the existing array.
+	ActionPrepend = "prepend" // The new array was prepended to the existing array.
+	ActionKeep    = "keep"    // The existing value was kept.
+	ActionClear   = "clear"   // The entire tree was cleared.
+
+	// ActionExpand is not produced by a merge, but is provided so callers
+	// describing how a value came to be can report variable expansion
+	// consistently.
+	ActionExpand = "expand"
+)
+
+// MergeEvent describes how a portion of the tree was altered by a merge.
+type MergeEvent struct {
+	// Path is the path to the portion of the tree that was altered.  An empty
+	// path is the root of the tree.
+	Path []string
+
+	// Action is what the merge did.  It is one of the Action constants.
+	Action string
+
+	// Command is the merge command specified in the key, if any.
+	Command string
+
+	// Result is a copy of the portion of the tree at the path after the
+	// action was applied.  For ActionClear the Result is empty, but contains
+	// the origins of the key that requested the clear.
+	Result Object
+}
+
+// mergeReporter tracks the path during a merge so events can be reported.
+type mergeReporter struct {
+	path []string
+	fn   func(MergeEvent)
+}
+
+// at returns a mergeReporter for the child key.
+func (r mergeReporter) at(key string) mergeReporter {
+	if r.fn == nil {
+		return r
+	}
+	return mergeReporter{
+		path: strs.Append(r.path, key),
+		fn:   r.fn,
+	}
+}
+
+// report sends the event to the reporter function if present.  The result is
+// cloned since later merges alter the tree in place.
+func (r mergeReporter) report(action string, cmd command, result Object) {
+	if r.fn == nil {
+		return
+	}
+	r.fn(MergeEvent{
+		Path:    r.path,
+		Action:  action,
+		Command: cmd.cmd,
+		Result:  result.Clone(),
+	})
+}
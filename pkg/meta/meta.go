@@ -32,6 +32,7 @@ var (
 	ErrInvalidIndex     = errors.New("invalid index")
 	ErrRecursionTooDeep = errors.New("recursion too deep")
 	ErrNonSerializable  = errors.New("non-serializeable objects encountered")
+	ErrVariableNotSet   = errors.New("required variable is not set")
 )
 
 // Origin provides details about an origin of a parameter.
@@ -223,11 +224,66 @@ func ObjectFromRawWithOrigin(in any, where []Origin, at ...string) (obj Object)
 // not being allowed.
 func (obj Object) Add(keyDelimiter, key string, val any, origin ...Origin) (Object, error) {
 	splitKey := strings.Split(key, keyDelimiter)
-	return obj.add(splitKey, val, origin...)
+	parts := make([]keyPart, len(splitKey))
+	for i, s := range splitKey {
+		parts[i] = keyPart{name: s}
+	}
+	return obj.add(parts, val, origin...)
+}
+
+// AddPath works like Add, except parts of the key may also end with index
+// segments that always refer to an array, creating the array if needed:
+//   - servers[1].port refers to the port of the second item in the servers array.
+//   - servers[+] refers to a new item appended to the servers array.
+//   - matrix[0][1] refers to an item in an array of arrays.
+func (obj Object) AddPath(keyDelimiter, key string, val any, origin ...Origin) (Object, error) {
+	parts, err := splitPath(keyDelimiter, key)
+	if err != nil {
+		return Object{}, err
+	}
+	return obj.add(parts, val, origin...)
+}
+
+// keyPart is a single part of a key passed to Add() or AddPath().
+type keyPart struct {
+	name  string
+	index bool // The name is an index segment; either a number or '+'.
+}
+
+// splitPath splits the key into parts using the delimiter and the index
+// segments.
+func splitPath(keyDelimiter, key string) ([]keyPart, error) {
+	var parts []keyPart
+	for _, s := range strings.Split(key, keyDelimiter) {
+		var indexes []keyPart
+		for strings.HasSuffix(s, "]") {
+			i := strings.LastIndex(s, "[")
+			if i < 0 {
+				return nil, fmt.Errorf("%w: key: '%s' has an unmatched ']'", ErrInvalidIndex, key)
+			}
+
+			idx := s[i+1 : len(s)-1]
+			if idx != "+" {
+				if n, err := strconv.Atoi(idx); err != nil || n < 0 || strings.HasPrefix(idx, "+") {
+					return nil, fmt.Errorf("%w: key: '%s' has an invalid index '%s'", ErrInvalidIndex, key, idx)
+				}
+			}
+
+			indexes = append([]keyPart{{name: idx, index: true}}, indexes...)
+			s = s[:i]
+		}
+
+		if s != "" || len(indexes) == 0 {
+			parts = append(parts, keyPart{name: s})
+		}
+		parts = append(parts, indexes...)
+	}
+
+	return parts, nil
 }
 
 // add is the internal helper that is recursively called to add to the tree.
-func (obj Object) add(keys []string, val any, origin ...Origin) (Object, error) {
+func (obj Object) add(keys []keyPart, val any, origin ...Origin) (Object, error) {
 	kind := obj.Kind()
 
 	if len(origin) == 0 {
@@ -242,13 +298,21 @@ func (obj Object) add(keys []string, val any, origin ...Origin) (Object, error)
 	}
 
 	key := keys[0]
-	if kind == Array {
-		idx, err := strconv.Atoi(key)
-		if err != nil {
-			return Object{}, fmt.Errorf("%w: index: '%s' %v", ErrInvalidIndex, key, err) //nolint:errorlint
+	if key.index && (0 < len(obj.Map) || obj.Value != nil) {
+		return Object{}, fmt.Errorf("%w: index: '[%s]' used with a non-array", ErrInvalidIndex, key.name)
+	}
+
+	if kind == Array || key.index {
+		idx := len(obj.Array)
+		if !key.index || key.name != "+" {
+			var err error
+			idx, err = strconv.Atoi(key.name)
+			if err != nil {
+				return Object{}, fmt.Errorf("%w: index: '%s' %v", ErrInvalidIndex, key.name, err) //nolint:errorlint
+			}
 		}
 		if idx < 0 || len(obj.Array) < idx {
-			return Object{}, fmt.Errorf("%w: index: '%s' must be %d", ErrArrayOutOfBounds, key, len(obj.Array))
+			return Object{}, fmt.Errorf("%w: index: '%s' must be %d", ErrArrayOutOfBounds, key.name, len(obj.Array))
 		}
 
 		sub := Object{
@@ -275,7 +339,7 @@ func (obj Object) add(keys []string, val any, origin ...Origin) (Object, error)
 		obj.Map = make(map[string]Object)
 	}
 
-	sub, found := obj.Map[key]
+	sub, found := obj.Map[key.name]
 	if !found {
 		sub = Object{
 			Origins: origin,
@@ -285,7 +349,7 @@ func (obj Object) add(keys []string, val any, origin ...Origin) (Object, error)
 	if err != nil {
 		return Object{}, err
 	}
-	obj.Map[key] = next
+	obj.Map[key.name] = next
 	return obj, nil
 }
 
@@ -346,13 +410,60 @@ func (obj Object) ToRedacted() Object {
 // from never returning.  Instead the process is stopped and an error is returned.
 // The resulting tree is returned.
 func (obj Object) ToExpanded(max int, origin, start, end string, expander func(string) (string, bool)) (Object, error) {
+	return obj.ToExpandedWithOptions(ExpandOptions{
+		Maximum: max,
+		Origin:  origin,
+		Start:   start,
+		End:     end,
+		Expander: func(s string) (string, bool, bool) {
+			val, found := expander(s)
+			return val, found, false
+		},
+	})
+}
+
+// ExpandOptions controls how the variables are expanded by
+// [Object.ToExpandedWithOptions].
+type ExpandOptions struct {
+	// Maximum is the number of expansions of a value allowed before the
+	// process is stopped and ErrRecursionTooDeep is returned.
+	Maximum int
+
+	// Origin is the origin added to the values that are expanded.
+	Origin string
+
+	// Start and End are the delimiters that surround a variable.
+	Start string
+	End   string
+
+	// Delimiter is the key delimiter, which may be used in the name of a
+	// variable with a shell style operator that doesn't start with ':', like
+	// ${db.port-5432}.
+	Delimiter string
+
+	// Expander provides the value of a variable, if it was found and if the
+	// value is a secret.  Any value that is expanded using a secret is marked
+	// as a secret.
+	Expander func(string) (val string, found, secret bool)
+
+	// Defer is optional and is called before a shell style operator is
+	// applied to a variable with a name that isn't set, which is not found or
+	// empty for the operators starting with ':'.  It is called with the full
+	// variable, the name and the operator.  If it returns true, the
+	// variable is left unexpanded so a later expansion may resolve it.
+	Defer func(key, name, op string) bool
+}
+
+// ToExpandedWithOptions is the same as ToExpanded except the expansion is
+// controlled by the options provided.
+func (obj Object) ToExpandedWithOptions(opts ExpandOptions) (Object, error) {
 	var err error
 
 	switch obj.Kind() {
 	case Array:
 		array := make([]Object, len(obj.Array))
 		for i, val := range obj.Array {
-			array[i], err = val.ToExpanded(max, origin, start, end, expander)
+			array[i], err = val.ToExpandedWithOptions(opts)
 			if err != nil {
 				return Object{}, err
 			}
@@ -362,7 +473,7 @@ func (obj Object) ToExpanded(max int, origin, start, end string, expander func(s
 		m := make(map[string]Object)
 
 		for key, val := range obj.Map {
-			m[key], err = val.ToExpanded(max, origin, start, end, expander)
+			m[key], err = val.ToExpandedWithOptions(opts)
 			if err != nil {
 				return Object{}, err
 			}
@@ -373,19 +484,27 @@ func (obj Object) ToExpanded(max int, origin, start, end string, expander func(s
 		case string:
 			// Limit the expansion to the max depth, but not the entire tree,
 			// just the value.
-			tmp := max
-			val, changed, err := expand(&tmp, v, start, end, expander)
+			tmp := opts.Maximum
+			var secret bool
+			val, changed, err := expand(&tmp, v, opts,
+				func(s string) (string, bool) {
+					got, found, isSecret := opts.Expander(s)
+					if found && isSecret {
+						secret = true
+					}
+					return got, found
+				})
 			if err != nil {
-				return Object{}, err
+				return Object{}, fmt.Errorf("%s: %w", obj.OriginString(), err)
 			}
 			origins := obj.Origins
 			if changed {
-				origins = append(origins, Origin{File: origin})
+				origins = append(origins, Origin{File: opts.Origin})
 			}
 			return Object{
 				Origins: origins,
 				Value:   val,
-				secret:  obj.secret,
+				secret:  obj.secret || secret,
 			}, nil
 		default:
 		}
@@ -396,19 +515,36 @@ func (obj Object) ToExpanded(max int, origin, start, end string, expander func(s
 
 // expand performs the expansion of a string based on the starting and ending
 // tokens as well as the mapping function & max replacement depth.
-func expand(max *int, in, startToken, endToken string, mapper func(string) (string, bool)) (string, bool, error) {
+//
+// The shell style operators are supported when the full variable isn't
+// found by the mapper:
+//
+//   - ${name:-word} is word if name is not found or is empty.
+//   - ${name-word} is word if name is not found.
+//   - ${name:?word} is an error with word as the message if name is not
+//     found or is empty.
+//   - ${name?word} is an error with word as the message if name is not found.
+//   - ${name:+word} is word if name is found and is not empty, or empty.
+//   - ${name+word} is word if name is found, or empty.
+//
+// See splitOperator for the names the operators are recognized after.  If
+// name isn't set and the Defer function returns true, the operator
+// isn't applied and the variable is left as is.
+func expand(max *int, in string, opts ExpandOptions, mapper func(string) (string, bool)) (string, bool, error) {
 	if *max < 1 {
 		return "", false, ErrRecursionTooDeep
 	}
 	*max--
 
+	startToken, endToken := opts.Start, opts.End
+
 	start := strings.Index(in, startToken)
 	if -1 == start {
 		return in, false, nil
 	}
 
 	rest := in[start+len(startToken):]
-	end := strings.Index(rest, endToken)
+	end := findEnd(rest, startToken, endToken)
 
 	if -1 == end {
 		return in, false, nil
@@ -421,7 +557,10 @@ func expand(max *int, in, startToken, endToken string, mapper func(string) (stri
 
 	var full string
 	var changed bool
-	got, found := mapper(key)
+	got, found, err := lookup(key, opts, mapper)
+	if err != nil {
+		return "", false, err
+	}
 	if found {
 		changed = true
 		full = before + got
@@ -429,7 +568,7 @@ func expand(max *int, in, startToken, endToken string, mapper func(string) (stri
 		full = before + startToken + key + endToken
 	}
 
-	last, expanded, err := expand(max, after, startToken, endToken, mapper)
+	last, expanded, err := expand(max, after, opts, mapper)
 	if err != nil {
 		return "", false, err
 	}
@@ -444,10 +583,153 @@ func expand(max *int, in, startToken, endToken string, mapper func(string) (stri
 		return in, false, nil
 	}
 
-	rv, _, err := expand(max, full, startToken, endToken, mapper)
+	rv, _, err := expand(max, full, opts, mapper)
 	return rv, true, err
 }
 
+// findEnd returns the index of the end token that closes the variable,
+// skipping over any variables nested inside of it.  If the variable isn't
+// closed, -1 is returned.
+func findEnd(s, startToken, endToken string) int {
+	var depth int
+	for i := 0; i < len(s); {
+		switch {
+		case strings.HasPrefix(s[i:], endToken):
+			if depth == 0 {
+				return i
+			}
+			depth--
+			i += len(endToken)
+		case strings.HasPrefix(s[i:], startToken):
+			depth++
+			i += len(startToken)
+		default:
+			i++
+		}
+	}
+
+	return -1
+}
+
+// lookup finds the value of the key using the mapper.  If the key isn't
+// found and it contains a shell style operator, the operator is applied unless
+// the name isn't set either and the Defer function returns true.
+func lookup(key string, opts ExpandOptions, mapper func(string) (string, bool)) (string, bool, error) {
+	if got, found := mapper(key); found {
+		return got, true, nil
+	}
+
+	name, op, word := splitOperator(key, opts)
+	if op == "" {
+		return "", false, nil
+	}
+
+	val, set := mapper(name)
+	if strings.HasPrefix(op, ":") {
+		set = set && val != ""
+	}
+	if !set && opts.Defer != nil && opts.Defer(key, name, op) {
+		return "", false, nil
+	}
+
+	switch op {
+	case ":-", "-":
+		if set {
+			return val, true, nil
+		}
+		return word, true, nil
+	case ":+", "+":
+		if set {
+			return word, true, nil
+		}
+		return "", true, nil
+	}
+
+	// The ":?" and "?" operators.
+	if set {
+		return val, true, nil
+	}
+	if word == "" {
+		word = "not set"
+	}
+	return "", false, fmt.Errorf("%w: '%s': %s", ErrVariableNotSet, name, word)
+}
+
+// splitOperator splits the key into the name, the shell style operator and
+// the word following the operator.  If there is no operator, the op is empty.
+//
+// The name of a ':' operator is everything before the first one that isn't in
+// a nested variable, so it may be a path or contain a scheme prefix like
+// "file:/run/secrets/db-pass:-none".  The name of an operator without a ':'
+// must be a shell identifier that may contain the key delimiter and have a
+// scheme prefix, so "my-var" is the name "my" with the "-" operator and
+// "file:/run/secrets/db-pass" isn't split.
+func splitOperator(key string, opts ExpandOptions) (name, op, word string) {
+	head := key
+	if opts.Start != "" {
+		head, _, _ = strings.Cut(key, opts.Start)
+	}
+
+	if i := indexColonOperator(head); i > 0 {
+		return key[:i], key[i : i+2], key[i+2:]
+	}
+
+	prefix := schemeLen(key)
+	i := prefix + identifierLen(key[prefix:], opts.Delimiter)
+	if i == prefix {
+		return key, "", ""
+	}
+
+	for _, op := range []string{"-", "?", "+"} {
+		if strings.HasPrefix(key[i:], op) {
+			return key[:i], op, key[i+len(op):]
+		}
+	}
+
+	return key, "", ""
+}
+
+// indexColonOperator returns the index of the first ":-", ":?" or ":+" in s,
+// or -1 if there isn't one.
+func indexColonOperator(s string) int {
+	for i := 0; i+1 < len(s); i++ {
+		if s[i] == ':' && strings.ContainsRune("-?+", rune(s[i+1])) {
+			return i
+		}
+	}
+	return -1
+}
+
+// schemeLen returns the length of the scheme prefix (an identifier followed
+// by ':') at the start of s, or 0 if there isn't one.
+func schemeLen(s string) int {
+	i := identifierLen(s, "")
+	if i == 0 || i == len(s) || s[i] != ':' {
+		return 0
+	}
+	return i + 1
+}
+
+// identifierLen returns the length of the shell identifier ([A-Za-z_] followed
+// by [A-Za-z0-9_]) at the start of s, or 0 if there isn't one.  The delimiter
+// is allowed after the first character if it isn't empty.
+func identifierLen(s, delimiter string) int {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case i > 0 && '0' <= c && c <= '9':
+		case i > 0 && delimiter != "" && strings.HasPrefix(s[i:], delimiter):
+			i += len(delimiter)
+			continue
+		default:
+			return i
+		}
+		i++
+	}
+	return len(s)
+}
+
 // ConvertMapsToArrays walks the object tree and looks for any maps that contain
 // only sequential numbers starting with 0.  If one is found, then it assumed to
 // be an array and restructured accordingly.
@@ -562,35 +844,48 @@ func (obj Object) resolveCommands(secret bool) (Object, error) {
 // Merge performs a merge of the new Object tree onto the existing Object tree
 // using the default semantics and merge rules found in the key commands.
 func (obj Object) Merge(next Object) (Object, error) {
+	return obj.MergeWithReporter(next, nil)
+}
+
+// MergeWithReporter performs the same merge as [Object.Merge], but calls the
+// reporter function with a [MergeEvent] each time a portion of the tree is
+// set, replaced, appended to, prepended to, kept or cleared.  A nil reporter
+// is allowed.
+func (obj Object) MergeWithReporter(next Object, reporter func(MergeEvent)) (Object, error) {
+	r := mergeReporter{fn: reporter}
+
 	// The 'clear' command is special in that if it is found at all, it
 	// overwrites everything else in the existing tree and exists the merge.
-	for k := range next.Map {
+	for k, v := range next.Map {
 		cmd, err := getCmd(k)
 		if err != nil {
 			return Object{}, err
 		}
 		if cmd.cmd == cmdClear {
-			return Object{Origins: []Origin{}}, nil
+			rv := Object{Origins: []Origin{}}
+			r.report(ActionClear, cmd, Object{Origins: v.Origins})
+			return rv, nil
 		}
 	}
 
-	return obj.merge(command{}, next)
+	return obj.merge(command{}, next, r)
 }
 
 // merge does the actual merging of the trees.
-func (obj Object) merge(cmd command, next Object) (Object, error) {
+func (obj Object) merge(cmd command, next Object, r mergeReporter) (Object, error) {
 	switch obj.Kind() {
 	case Value:
-		return obj.mergeValue(cmd, next)
+		return obj.mergeValue(cmd, next, r)
 	case Array:
-		return obj.mergeArray(cmd, next)
+		return obj.mergeArray(cmd, next, r)
 	}
-	return obj.mergeMap(cmd, next)
+	return obj.mergeMap(cmd, next, r)
 }
 
 // mergeValue merges two values.  Don't directly call this, call merge() instead.
-func (obj Object) mergeValue(cmd command, next Object) (Object, error) {
+func (obj Object) mergeValue(cmd command, next Object, r mergeReporter) (Object, error) {
 	rv := obj
+	action := ActionKeep
 	switch cmd.cmd {
 	case cmdReplace, "":
 		var err error
@@ -598,22 +893,28 @@ func (obj Object) mergeValue(cmd command, next Object) (Object, error) {
 		if err != nil {
 			return Object{}, err
 		}
+		action = ActionReplace
+		if obj.IsEmpty() {
+			action = ActionSet
+		}
 	case cmdFail:
 		return Object{}, fmt.Errorf("%w: merging a value with command 'fail'", ErrConflict)
 	case cmdKeep:
 	}
 
 	rv.secret = cmd.secret
+	r.report(action, cmd, rv)
 	return rv, nil
 }
 
 // mergeArray merges two array.  Don't directly call this, call merge() instead.
-func (obj Object) mergeArray(cmd command, next Object) (Object, error) {
+func (obj Object) mergeArray(cmd command, next Object, r mergeReporter) (Object, error) {
 	rv := obj
 	next, err := next.resolveCommands(obj.secret)
 	if err != nil {
 		return Object{}, err
 	}
+	action := ActionKeep
 	switch cmd.cmd {
 	case cmdAppend, "":
 		if obj.secret || next.secret || cmd.secret {
@@ -621,28 +922,33 @@ func (obj Object) mergeArray(cmd command, next Object) (Object, error) {
 		}
 		rv.Origins = append(obj.Origins, next.Origins...)
 		rv.Array = append(obj.Array, next.Array...)
+		action = ActionAppend
 	case cmdPrepend:
 		if obj.secret || next.secret || cmd.secret {
 			rv.secret = true
 		}
 		rv.Origins = append(next.Origins, obj.Origins...)
 		rv.Array = append(next.Array, obj.Array...)
+		action = ActionPrepend
 	case cmdReplace:
 		rv.secret = cmd.secret
 		rv = next
+		action = ActionReplace
 	case cmdKeep:
 	case cmdFail:
 		return Object{}, fmt.Errorf("%w: merging an array with command 'fail'", ErrConflict)
 	}
+	r.report(action, cmd, rv)
 	return rv, nil
 }
 
 // mergeMap merges two maps.  Don't directly call this, call merge() instead.
-func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
+func (obj Object) mergeMap(cmd command, next Object, r mergeReporter) (Object, error) {
 	switch cmd.cmd {
 	case cmdFail:
 		return Object{}, fmt.Errorf("%w: merging a map with command 'fail'", ErrConflict)
 	case cmdKeep:
+		r.report(ActionKeep, cmd, obj)
 		return obj, nil
 	case cmdReplace:
 		rv, err := next.resolveCommands(false)
@@ -650,6 +956,7 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 			return Object{}, err
 		}
 		rv.secret = cmd.secret
+		r.report(ActionReplace, cmd, rv)
 		return rv, nil
 	default:
 	}
@@ -661,6 +968,7 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 			return Object{}, err
 		}
 
+		child := r.at(newCmd.final)
 		existing, found := obj.Map[newCmd.final]
 		if !found {
 			// Merging with no conflicts.
@@ -669,11 +977,12 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 				return Object{}, err
 			}
 			obj.Map[newCmd.final] = v
+			child.report(ActionSet, newCmd, v)
 			continue
 		}
 
 		if existing.Kind() == val.Kind() {
-			v, err := existing.merge(newCmd, val)
+			v, err := existing.merge(newCmd, val, child)
 			if err != nil {
 				return Object{}, err
 			}
@@ -688,8 +997,10 @@ func (obj Object) mergeMap(cmd command, next Object) (Object, error) {
 				return Object{}, err
 			}
 			obj.Map[newCmd.final] = v
+			child.report(ActionReplace, newCmd, v)
 		case cmdKeep:
 			obj.Map[newCmd.final] = existing
+			child.report(ActionKeep, newCmd, existing)
 		case cmdFail:
 			return Object{}, fmt.Errorf("%w: merging map", ErrConflict)
 		}
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -483,6 +485,30 @@ func TestAdd(t *testing.T) {
 					},
 				},
 			},
+		}, {
+			description: "Index segments are part of the key.",
+			key:         "Foo[0]",
+			val:         "abc",
+			expected: Object{
+				Map: map[string]Object{
+					"Foo[0]": {Origins: []Origin{{}}, Value: "abc"},
+				},
+			},
+		}, {
+			description: "Fail with a plus as an array index.",
+			key:         "Foo.+",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+						},
+					},
+				},
+			},
 		},
 	}
 	for _, tc := range tests {
@@ -508,6 +534,215 @@ func TestAdd(t *testing.T) {
 	}
 }
 
+func TestAddPath(t *testing.T) {
+	tests := []struct {
+		description string
+		key         string
+		val         string
+		start       Object
+		origin      *Origin
+		expected    Object
+		expectedErr error
+	}{
+		{
+			description: "Add to an empty tree.",
+			key:         "Foo.Bar",
+			val:         "abc",
+			expected: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Map: map[string]Object{
+							"Bar": {Origins: []Origin{{}}, Value: "abc"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Change a value in an array using an index.",
+			key:         "Foo[1].Bar",
+			val:         "---",
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+							{
+								Origins: []Origin{{}},
+								Map: map[string]Object{
+									"Bar": {Origins: []Origin{{}}, Value: "xyz"},
+									"Baz": {Origins: []Origin{{}}, Value: "123"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+							{
+								Origins: []Origin{{}},
+								Map: map[string]Object{
+									"Bar": {Origins: []Origin{{}}, Value: "---"},
+									"Baz": {Origins: []Origin{{}}, Value: "123"},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Append to an array.",
+			key:         "Foo[+]",
+			val:         "xyz",
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+						},
+					},
+				},
+			},
+			expected: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+							{Origins: []Origin{{}}, Value: "xyz"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Create arrays using indexes.",
+			key:         "Foo[0][+].Bar[0]",
+			val:         "abc",
+			origin:      &Origin{File: "file"},
+			expected: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{File: "file"}},
+						Array: []Object{
+							{
+								Origins: []Origin{{File: "file"}},
+								Array: []Object{
+									{
+										Origins: []Origin{{File: "file"}},
+										Map: map[string]Object{
+											"Bar": {
+												Origins: []Origin{{File: "file"}},
+												Array: []Object{
+													{Origins: []Origin{{File: "file"}}, Value: "abc"},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Add to a root array using an index.",
+			key:         "[+]",
+			val:         "abc",
+			start: Object{
+				Array: []Object{},
+			},
+			expected: Object{
+				Array: []Object{
+					{Origins: []Origin{{}}, Value: "abc"},
+				},
+			},
+		}, {
+			description: "Fail with an index that is too large.",
+			key:         "Foo[2]",
+			val:         "---",
+			expectedErr: ErrArrayOutOfBounds,
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Array: []Object{
+							{Origins: []Origin{{}}, Value: "abc"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Fail with an index used on a map.",
+			key:         "Foo[0]",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {
+						Origins: []Origin{{}},
+						Map: map[string]Object{
+							"Bar": {Origins: []Origin{{}}, Value: "abc"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "Fail with an index used on a value.",
+			key:         "Foo[+]",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+			start: Object{
+				Map: map[string]Object{
+					"Foo": {Origins: []Origin{{}}, Value: "abc"},
+				},
+			},
+		}, {
+			description: "Fail with an invalid index.",
+			key:         "Foo[x]",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+		}, {
+			description: "Fail with a negative index.",
+			key:         "Foo[-1]",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+		}, {
+			description: "Fail with an unmatched bracket.",
+			key:         "Foo]",
+			val:         "---",
+			expectedErr: ErrInvalidIndex,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got Object
+			var err error
+			if tc.origin == nil {
+				got, err = tc.start.AddPath(".", tc.key, tc.val)
+			} else {
+				got, err = tc.start.AddPath(".", tc.key, tc.val, *tc.origin)
+			}
+
+			if tc.expectedErr == nil {
+				assert.NoError(err)
+				assert.Equal(tc.expected, got)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+		})
+	}
+}
+
 func TestConvertMapsToArrays(t *testing.T) {
 	empty := []Origin{{}}
 	tests := []struct {
@@ -1007,12 +1242,75 @@ func TestToExpanded(t *testing.T) {
 	}
 }
 
+func TestToExpandedWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	file := []Origin{{File: "file"}}
+	exp := []Origin{{File: "file"}, {File: "exp"}}
+
+	in := Object{
+		Origins: file,
+		Map: map[string]Object{
+			"plain":   {Origins: file, Value: "${name}"},
+			"mixed":   {Origins: file, Value: "${name}:${password}"},
+			"secret":  {Origins: file, Value: "${password}"},
+			"missing": {Origins: file, Value: "${missing}"},
+			"list": {
+				Origins: file,
+				Array: []Object{
+					{Origins: file, Value: "${password}"},
+				},
+			},
+		},
+	}
+
+	expected := Object{
+		Origins: file,
+		Map: map[string]Object{
+			"plain":   {Origins: exp, Value: "user"},
+			"mixed":   {Origins: exp, Value: "user:pass", secret: true},
+			"secret":  {Origins: exp, Value: "pass", secret: true},
+			"missing": {Origins: file, Value: "${missing}"},
+			"list": {
+				Origins: file,
+				Array: []Object{
+					{Origins: exp, Value: "pass", secret: true},
+				},
+			},
+		},
+	}
+
+	got, err := in.ToExpandedWithOptions(ExpandOptions{
+		Maximum: 100,
+		Origin:  "exp",
+		Start:   "${",
+		End:     "}",
+		Expander: func(s string) (string, bool, bool) {
+			switch s {
+			case "name":
+				return "user", true, false
+			case "password":
+				return "pass", true, true
+			case "missing":
+				return "", false, true
+			}
+			return "", false, false
+		},
+	})
+
+	assert.NoError(err)
+	assert.Equal(expected, got)
+	assert.Equal(redactedText, got.ToRedacted().Map["secret"].Value)
+}
+
 func TestExpand(t *testing.T) {
 	tests := []struct {
 		in          string
 		start       string
 		end         string
+		delimiter   string
 		vars        map[string]string
+		deferred    []string
 		expected    string
 		expectedErr error
 		changed     bool
@@ -1058,6 +1356,166 @@ func TestExpand(t *testing.T) {
 			},
 			expected: "${nothing}",
 			changed:  true,
+		}, {
+			in:    "${set:-default} ${empty:-default} ${unset:-default}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"set":   "value",
+				"empty": "",
+			},
+			expected: "value default default",
+			changed:  true,
+		}, {
+			in:    "${set-default} ${empty-default} ${unset-default}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"set":   "value",
+				"empty": "",
+			},
+			expected: "value  default",
+			changed:  true,
+		}, {
+			in:    "[${set:+alt}] [${empty:+alt}] [${unset:+alt}]",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"set":   "value",
+				"empty": "",
+			},
+			expected: "[alt] [] []",
+			changed:  true,
+		}, {
+			in:    "[${set+alt}] [${empty+alt}] [${unset+alt}]",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"set":   "value",
+				"empty": "",
+			},
+			expected: "[alt] [alt] []",
+			changed:  true,
+		}, {
+			in:    "${set:?required} ${empty?required}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"set":   "value",
+				"empty": "",
+			},
+			expected: "value ",
+			changed:  true,
+		}, {
+			in:          "${empty:?required}",
+			start:       "${",
+			end:         "}",
+			vars:        map[string]string{"empty": ""},
+			expectedErr: ErrVariableNotSet,
+		}, {
+			in:          "${unset?}",
+			start:       "${",
+			end:         "}",
+			expectedErr: ErrVariableNotSet,
+		}, {
+			in:    "${unset:-${other:-${last}}}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"last": "nested",
+			},
+			expected: "nested",
+			changed:  true,
+		}, {
+			in:    "${{unset:-a-b}} ${{a-b}} ${{:-x}} ${{-x}}",
+			start: "${{",
+			end:   "}}",
+			vars: map[string]string{
+				"a-b": "found",
+			},
+			expected: "a-b found ${{:-x}} ${{-x}}",
+			changed:  true,
+		}, {
+			in:       "${file:/run/secrets/db-pass} ${1st-x} ${a.b-x} ${-x}",
+			start:    "${",
+			end:      "}",
+			vars:     map[string]string{"file": "value", "1st": "value", "a": "value"},
+			expected: "${file:/run/secrets/db-pass} ${1st-x} ${a.b-x} ${-x}",
+		}, {
+			in:       "${my-var}",
+			start:    "${",
+			end:      "}",
+			expected: "var",
+			changed:  true,
+		}, {
+			in:    "${env:PORT:-8080} ${env:HOST-localhost} ${env:TLS:+https} ${file:/run/secrets/db-pass:-none}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"env:TLS": "true",
+				"file":    "value",
+			},
+			expected: "8080 localhost https none",
+			changed:  true,
+		}, {
+			in:    "${env:PORT:-8080} ${file:/run/secrets/db-pass:?the password is required}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"env:PORT":                  "80",
+				"file:/run/secrets/db-pass": "secret",
+			},
+			expected: "80 secret",
+			changed:  true,
+		}, {
+			in:          "${file:/run/secrets/db-pass:?the password is required}",
+			start:       "${",
+			end:         "}",
+			expectedErr: ErrVariableNotSet,
+		}, {
+			in:        "${db.host:-localhost} ${db.port-5432} ${db.user:-admin} ${list.0-first}",
+			start:     "${",
+			end:       "}",
+			delimiter: ".",
+			vars: map[string]string{
+				"db.user": "me",
+			},
+			expected: "localhost 5432 me first",
+			changed:  true,
+		}, {
+			in:       "${db.port-5432}",
+			start:    "${",
+			end:      "}",
+			expected: "${db.port-5432}",
+		}, {
+			in:    "${my-var} ${file:/run/secrets/db-pass}",
+			start: "${",
+			end:   "}",
+			vars: map[string]string{
+				"my-var":                    "hyphen",
+				"file:/run/secrets/db-pass": "secret",
+			},
+			expected: "hyphen secret",
+			changed:  true,
+		}, {
+			in:       "${unset:-default} ${later:-default} ${later:?required} ${later+alt}",
+			start:    "${",
+			end:      "}",
+			deferred: []string{"later"},
+			expected: "default ${later:-default} ${later:?required} ${later+alt}",
+			changed:  true,
+		}, {
+			in:       "${set:-default}",
+			start:    "${",
+			end:      "}",
+			vars:     map[string]string{"set": ""},
+			deferred: []string{"set"},
+			expected: "${set:-default}",
+		}, {
+			in:       "${unset:-${unclosed}",
+			start:    "${",
+			end:      "}",
+			expected: "${unset:-${unclosed}",
 		},
 	}
 	for _, tc := range tests {
@@ -1065,7 +1523,15 @@ func TestExpand(t *testing.T) {
 			assert := assert.New(t)
 
 			max := int(100)
-			got, _, err := expand(&max, tc.in, tc.start, tc.end, func(in string) (string, bool) {
+			opts := ExpandOptions{
+				Start:     tc.start,
+				End:       tc.end,
+				Delimiter: tc.delimiter,
+				Defer: func(_, name, _ string) bool {
+					return slices.Contains(tc.deferred, name)
+				},
+			}
+			got, _, err := expand(&max, tc.in, opts, func(in string) (string, bool) {
 				out, found := tc.vars[in]
 				return out, found
 			})
@@ -1964,3 +2430,105 @@ func TestClone(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeWithReporter(t *testing.T) {
+	tests := []struct {
+		description string
+		start       string
+		next        string
+		expected    []MergeEvent
+	}{
+		{
+			description: "set into an empty tree",
+			next:        `{"a":"b"}`,
+			expected: []MergeEvent{
+				{Action: ActionSet, Result: decode(`{"a":"b"}`)},
+			},
+		}, {
+			description: "set, replace and keep",
+			start:       `{"a":"b", "c":"d", "e":{"f":"g"}}`,
+			next:        `{"a":"x", "c((keep))":"y", "h":"i", "e":{"f((replace))":"z"}}`,
+			expected: []MergeEvent{
+				{Path: []string{"a"}, Action: ActionReplace, Result: decode(`"x"`)},
+				{Path: []string{"c"}, Action: ActionKeep, Command: "keep", Result: decode(`"d"`)},
+				{Path: []string{"e", "f"}, Action: ActionReplace, Command: "replace", Result: decode(`"z"`)},
+				{Path: []string{"h"}, Action: ActionSet, Result: decode(`"i"`)},
+			},
+		}, {
+			description: "arrays",
+			start:       `{"a":["b"], "c":["d"], "e":["f"], "g":["h"]}`,
+			next:        `{"a":["x"], "c((prepend))":["y"], "e((replace))":["z"], "g((keep))":["w"]}`,
+			expected: []MergeEvent{
+				{Path: []string{"a"}, Action: ActionAppend, Result: decode(`["b","x"]`)},
+				{Path: []string{"c"}, Action: ActionPrepend, Command: "prepend", Result: decode(`["y","d"]`)},
+				{Path: []string{"e"}, Action: ActionReplace, Command: "replace", Result: decode(`["z"]`)},
+				{Path: []string{"g"}, Action: ActionKeep, Command: "keep", Result: decode(`["h"]`)},
+			},
+		}, {
+			description: "maps",
+			start:       `{"a":{"b":"c"}, "d":{"e":"f"}, "g":{"h":"i"}}`,
+			next:        `{"a((replace))":{"x":"y"}, "d((keep))":{"x":"y"}, "g":"value"}`,
+			expected: []MergeEvent{
+				{Path: []string{"a"}, Action: ActionReplace, Command: "replace", Result: decode(`{"x":"y"}`)},
+				{Path: []string{"d"}, Action: ActionKeep, Command: "keep", Result: decode(`{"e":"f"}`)},
+				{Path: []string{"g"}, Action: ActionReplace, Result: decode(`"value"`)},
+			},
+		}, {
+			description: "different kinds kept",
+			start:       `{"a":{"b":"c"}}`,
+			next:        `{"a((keep))":"value"}`,
+			expected: []MergeEvent{
+				{Path: []string{"a"}, Action: ActionKeep, Command: "keep", Result: decode(`{"b":"c"}`)},
+			},
+		}, {
+			description: "clear",
+			start:       `{"a":"b"}`,
+			next:        `{"ignored((clear))":"ignored"}`,
+			expected: []MergeEvent{
+				{Action: ActionClear, Command: "clear", Result: Object{Origins: []Origin{}}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			start := Object{Map: map[string]Object{}}
+			if tc.start != "" {
+				start = decode(tc.start)
+			}
+
+			var got []MergeEvent
+			_, err := start.MergeWithReporter(decode(tc.next), func(e MergeEvent) {
+				got = append(got, e)
+			})
+			require.NoError(err)
+
+			// Map iteration order isn't stable.
+			sort.Slice(got, func(i, j int) bool {
+				return strings.Join(got[i].Path, ".") < strings.Join(got[j].Path, ".")
+			})
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestMergeReportedResultsAreCopies(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var got []MergeEvent
+	reporter := func(e MergeEvent) {
+		got = append(got, e)
+	}
+
+	tree, err := Object{Map: map[string]Object{}}.MergeWithReporter(decode(`{"a":{"b":"c"}}`), reporter)
+	require.NoError(err)
+	_, err = tree.MergeWithReporter(decode(`{"a":{"d":"e"}}`), reporter)
+	require.NoError(err)
+
+	require.Len(got, 2)
+	assert.Equal(decode(`{"a":{"b":"c"}}`), got[0].Result)
+	assert.Equal([]string{"a", "d"}, got[1].Path)
+}
@@ -0,0 +1,796 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ decoder.Decoder = (*Decoder)(nil)
+
+// ErrSyntax is returned when the document isn't valid TOML.
+var ErrSyntax = errors.New("toml syntax error")
+
+// Decoder is a TOML decoder that records the origin of each key.
+//
+// The origin of a value is the position of its key, so the line reported is
+// the one the key is on.  The origin of a table is the position of the key
+// that first defined it and the origin of each table in an array of tables
+// is the position of its header.  Values in arrays have the position where
+// the value starts.
+//
+// Integers are decoded as int64 values and floats as float64 values.  Dates
+// and times are decoded as the strings found in the document so they can be
+// converted by the adapters into the desired type.
+type Decoder struct{}
+
+// Extensions returns the supported extensions.
+func (Decoder) Extensions() []string {
+	return []string{"toml"}
+}
+
+// Decode decodes a TOML document into the meta.Object tree.  A document
+// without any keys results in an empty tree.
+func (Decoder) Decode(ctx decoder.Context, b []byte, m *meta.Object) error {
+	p := parser{
+		file: ctx.Filename,
+		data: string(b),
+	}
+
+	obj, err := p.parse()
+	if err != nil {
+		return err
+	}
+
+	*m = obj
+	return nil
+}
+
+// The kinds of nodes.
+const (
+	tableNode = iota
+	arrayNode
+	valueNode
+)
+
+// node is an intermediate representation of the document that tracks how
+// each table was defined, since tables may only be defined once.
+type node struct {
+	kind     int
+	origin   meta.Origin
+	children map[string]*node // Used by tables.
+	elements []*node          // Used by arrays of tables.
+	value    meta.Object      // Used by values.
+	explicit bool             // The table was defined by a header.
+	dotted   bool             // The table was defined by a dotted key.
+}
+
+func newTable(origin meta.Origin) *node {
+	return &node{
+		kind:     tableNode,
+		origin:   origin,
+		children: make(map[string]*node),
+	}
+}
+
+func (n *node) toObject() meta.Object {
+	switch n.kind {
+	case tableNode:
+		obj := meta.Object{
+			Origins: []meta.Origin{n.origin},
+			Map:     make(map[string]meta.Object, len(n.children)),
+		}
+		for key, child := range n.children {
+			obj.Map[key] = child.toObject()
+		}
+		return obj
+	case arrayNode:
+		obj := meta.Object{
+			Origins: []meta.Origin{n.origin},
+			Array:   make([]meta.Object, len(n.elements)),
+		}
+		for i, elem := range n.elements {
+			obj.Array[i] = elem.toObject()
+		}
+		return obj
+	}
+
+	return n.value
+}
+
+// key is a single part of a (possibly dotted) key.
+type key struct {
+	name string
+	pos  int
+}
+
+func keyString(keys []key) string {
+	list := make([]string, len(keys))
+	for i, k := range keys {
+		list[i] = k.name
+	}
+	return strings.Join(list, ".")
+}
+
+// parser is a recursive descent parser of TOML documents.
+type parser struct {
+	file    string
+	data    string
+	pos     int
+	lines   []int
+	root    *node
+	current *node
+}
+
+func (p *parser) parse() (meta.Object, error) {
+	p.root = newTable(p.origin(0))
+	p.current = p.root
+
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+
+		switch p.peek() {
+		case '#', '\r', '\n':
+		case '[':
+			if err := p.header(); err != nil {
+				return meta.Object{}, err
+			}
+		default:
+			if err := p.keyValue(p.current); err != nil {
+				return meta.Object{}, err
+			}
+		}
+
+		if err := p.endOfLine(); err != nil {
+			return meta.Object{}, err
+		}
+	}
+
+	if len(p.root.children) == 0 {
+		return meta.Object{}, nil
+	}
+	return p.root.toObject(), nil
+}
+
+// -- Document structure -------------------------------------------------------
+
+// header handles both table and array of tables headers.
+func (p *parser) header() error {
+	start := p.pos
+	p.pos++
+
+	array := p.consume("[")
+
+	keys, err := p.keys()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if !p.consume("]") || (array && !p.consume("]")) {
+		return p.errorf(p.pos, "expected the end of the table header")
+	}
+
+	t := p.root
+	for _, k := range keys[:len(keys)-1] {
+		child, found := t.children[k.name]
+		if !found {
+			child = newTable(p.origin(k.pos))
+			t.children[k.name] = child
+		}
+
+		switch child.kind {
+		case arrayNode:
+			child = child.elements[len(child.elements)-1]
+		case valueNode:
+			return p.errorf(k.pos, "key '%s' is already defined as a value", keyString(keys))
+		}
+		t = child
+	}
+
+	last := keys[len(keys)-1]
+	child, found := t.children[last.name]
+
+	if array {
+		if !found {
+			child = &node{
+				kind:   arrayNode,
+				origin: p.origin(last.pos),
+			}
+			t.children[last.name] = child
+		}
+		if child.kind != arrayNode {
+			return p.errorf(last.pos, "key '%s' is already defined", keyString(keys))
+		}
+
+		elem := newTable(p.origin(start))
+		elem.explicit = true
+		child.elements = append(child.elements, elem)
+		p.current = elem
+		return nil
+	}
+
+	if !found {
+		child = newTable(p.origin(last.pos))
+		t.children[last.name] = child
+	} else if child.kind != tableNode || child.explicit || child.dotted {
+		return p.errorf(last.pos, "key '%s' is already defined", keyString(keys))
+	}
+
+	child.explicit = true
+	p.current = child
+	return nil
+}
+
+// keyValue handles a key value pair, adding it to the table.
+func (p *parser) keyValue(t *node) error {
+	keys, err := p.keys()
+	if err != nil {
+		return err
+	}
+
+	p.skipSpace()
+	if !p.consume("=") {
+		return p.errorf(p.pos, "expected '=' after the key")
+	}
+	p.skipSpace()
+
+	val, err := p.value()
+	if err != nil {
+		return err
+	}
+
+	for _, k := range keys[:len(keys)-1] {
+		child, found := t.children[k.name]
+		if !found {
+			child = newTable(p.origin(k.pos))
+			child.dotted = true
+			t.children[k.name] = child
+		}
+
+		// Only tables defined by dotted keys may be extended by them.
+		if child.kind != tableNode || !child.dotted {
+			return p.errorf(k.pos, "key '%s' is already defined", keyString(keys))
+		}
+		t = child
+	}
+
+	last := keys[len(keys)-1]
+	if _, found := t.children[last.name]; found {
+		return p.errorf(last.pos, "key '%s' is already defined", keyString(keys))
+	}
+
+	val.Origins = []meta.Origin{p.origin(last.pos)}
+	t.children[last.name] = &node{
+		kind:  valueNode,
+		value: val,
+	}
+	return nil
+}
+
+// keys reads a key made of one or more parts separated by dots.
+func (p *parser) keys() ([]key, error) {
+	var keys []key
+	for {
+		p.skipSpace()
+
+		k := key{pos: p.pos}
+		if p.eof() {
+			return nil, p.errorf(p.pos, "expected a key")
+		}
+
+		switch p.peek() {
+		case '"':
+			s, err := p.basicString()
+			if err != nil {
+				return nil, err
+			}
+			k.name = s
+		case '\'':
+			s, err := p.literalString()
+			if err != nil {
+				return nil, err
+			}
+			k.name = s
+		default:
+			for !p.eof() && isBare(p.peek()) {
+				p.pos++
+			}
+			if p.pos == k.pos {
+				return nil, p.errorf(p.pos, "expected a key")
+			}
+			k.name = p.data[k.pos:p.pos]
+		}
+		keys = append(keys, k)
+
+		p.skipSpace()
+		if !p.consume(".") {
+			return keys, nil
+		}
+	}
+}
+
+func isBare(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') ||
+		('0' <= c && c <= '9') || c == '_' || c == '-'
+}
+
+// -- Values -------------------------------------------------------------------
+
+// value reads any value.
+func (p *parser) value() (meta.Object, error) {
+	if p.eof() {
+		return meta.Object{}, p.errorf(p.pos, "expected a value")
+	}
+
+	obj := meta.Object{
+		Origins: []meta.Origin{p.origin(p.pos)},
+	}
+
+	var err error
+	switch p.peek() {
+	case '"':
+		obj.Value, err = p.basicString()
+	case '\'':
+		obj.Value, err = p.literalString()
+	case '[':
+		obj.Array, err = p.array()
+	case '{':
+		obj.Map, err = p.inlineTable()
+	default:
+		obj.Value, err = p.scalar()
+	}
+
+	return obj, err
+}
+
+func (p *parser) array() ([]meta.Object, error) {
+	start := p.pos
+	p.pos++
+
+	list := []meta.Object{}
+	for {
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated array")
+		}
+		if p.consume("]") {
+			return list, nil
+		}
+
+		val, err := p.value()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, val)
+
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated array")
+		}
+		if p.consume("]") {
+			return list, nil
+		}
+		if !p.consume(",") {
+			return nil, p.errorf(p.pos, "expected ',' or ']' in the array")
+		}
+	}
+}
+
+func (p *parser) inlineTable() (map[string]meta.Object, error) {
+	start := p.pos
+	p.pos++
+
+	t := newTable(p.origin(start))
+	for {
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated inline table")
+		}
+		if p.consume("}") {
+			break
+		}
+
+		// Dotted keys are allowed to extend the tables they define, but the
+		// inline table itself may not be extended after it is defined.
+		if err := p.keyValue(t); err != nil {
+			return nil, err
+		}
+
+		if err := p.skipBlank(); err != nil {
+			return nil, err
+		}
+		if p.eof() {
+			return nil, p.errorf(start, "unterminated inline table")
+		}
+		if p.consume("}") {
+			break
+		}
+		if !p.consume(",") {
+			return nil, p.errorf(p.pos, "expected ',' or '}' in the inline table")
+		}
+	}
+
+	return t.toObject().Map, nil
+}
+
+var (
+	decimalRE = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)$`)
+	hexRE     = regexp.MustCompile(`^0x[0-9A-Fa-f](_?[0-9A-Fa-f])*$`)
+	octalRE   = regexp.MustCompile(`^0o[0-7](_?[0-7])*$`)
+	binaryRE  = regexp.MustCompile(`^0b[01](_?[01])*$`)
+	floatRE   = regexp.MustCompile(`^[+-]?(0|[1-9](_?[0-9])*)(\.[0-9](_?[0-9])*)?([eE][+-]?[0-9](_?[0-9])*)?$`)
+	dateRE    = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+)
+
+// dateTimeLayouts are the layouts used to validate the dates and times.
+var dateTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+	"15:04:05.999999999",
+}
+
+// scalar reads the values that are not strings, arrays or inline tables.
+func (p *parser) scalar() (any, error) {
+	start := p.pos
+	p.token()
+
+	// A date and time may be separated by a space instead of a 'T'.
+	if dateRE.MatchString(p.data[start:p.pos]) && p.pos+3 < len(p.data) &&
+		p.data[p.pos] == ' ' && isDigit(p.data[p.pos+1]) && isDigit(p.data[p.pos+2]) &&
+		p.data[p.pos+3] == ':' {
+		p.pos++
+		p.token()
+	}
+
+	tok := p.data[start:p.pos]
+	switch tok {
+	case "":
+		return nil, p.errorf(start, "expected a value")
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "inf", "+inf":
+		return math.Inf(1), nil
+	case "-inf":
+		return math.Inf(-1), nil
+	case "nan", "+nan", "-nan":
+		return math.NaN(), nil
+	}
+
+	digits := strings.ReplaceAll(tok, "_", "")
+	switch {
+	case decimalRE.MatchString(tok):
+		if i, err := strconv.ParseInt(digits, 10, 64); err == nil {
+			return i, nil
+		}
+	case hexRE.MatchString(tok):
+		if i, err := strconv.ParseInt(digits[2:], 16, 64); err == nil {
+			return i, nil
+		}
+	case octalRE.MatchString(tok):
+		if i, err := strconv.ParseInt(digits[2:], 8, 64); err == nil {
+			return i, nil
+		}
+	case binaryRE.MatchString(tok):
+		if i, err := strconv.ParseInt(digits[2:], 2, 64); err == nil {
+			return i, nil
+		}
+	case floatRE.MatchString(tok):
+		if f, err := strconv.ParseFloat(digits, 64); err == nil {
+			return f, nil
+		}
+	default:
+		normal := strings.NewReplacer(" ", "T", "t", "T", "z", "Z").Replace(tok)
+		for _, layout := range dateTimeLayouts {
+			if _, err := time.Parse(layout, normal); err == nil {
+				return tok, nil
+			}
+		}
+		return nil, p.errorf(start, "invalid value '%s'", tok)
+	}
+
+	return nil, p.errorf(start, "the number '%s' is out of range", tok)
+}
+
+// token advances past the characters that may be part of a scalar value.
+func (p *parser) token() {
+	for !p.eof() && !strings.ContainsRune(" \t\r\n,]}#", rune(p.peek())) {
+		p.pos++
+	}
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// -- Strings ------------------------------------------------------------------
+
+// basicString reads a single or multi-line basic string.
+func (p *parser) basicString() (string, error) {
+	start := p.pos
+	multi := strings.HasPrefix(p.data[p.pos:], `"""`)
+	if multi {
+		p.pos += 3
+		p.trimNewline()
+	} else {
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf(start, "unterminated string")
+		}
+
+		c := p.peek()
+		switch {
+		case c == '"':
+			if !multi {
+				p.pos++
+				return b.String(), nil
+			}
+			if done, err := p.closeMulti(&b, '"', start); done || err != nil {
+				return b.String(), err
+			}
+		case c == '\\':
+			if multi && p.lineEndingBackslash() {
+				continue
+			}
+			if err := p.escape(&b); err != nil {
+				return "", err
+			}
+		case c == '\n' && !multi:
+			return "", p.errorf(start, "unterminated string")
+		default:
+			r, size := utf8.DecodeRuneInString(p.data[p.pos:])
+			b.WriteRune(r)
+			p.pos += size
+		}
+	}
+}
+
+// literalString reads a single or multi-line literal string.
+func (p *parser) literalString() (string, error) {
+	start := p.pos
+	multi := strings.HasPrefix(p.data[p.pos:], `'''`)
+	if multi {
+		p.pos += 3
+		p.trimNewline()
+	} else {
+		p.pos++
+	}
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf(start, "unterminated string")
+		}
+
+		c := p.peek()
+		switch {
+		case c == '\'':
+			if !multi {
+				p.pos++
+				return b.String(), nil
+			}
+			if done, err := p.closeMulti(&b, '\'', start); done || err != nil {
+				return b.String(), err
+			}
+		case c == '\n' && !multi:
+			return "", p.errorf(start, "unterminated string")
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// closeMulti handles a run of quotes in a multi-line string.  Up to two
+// quotes may precede the closing delimiter.
+func (p *parser) closeMulti(b *strings.Builder, quote byte, start int) (bool, error) {
+	n := 0
+	for p.pos+n < len(p.data) && p.data[p.pos+n] == quote {
+		n++
+	}
+	p.pos += n
+
+	if n < 3 {
+		b.WriteString(strings.Repeat(string(quote), n))
+		return false, nil
+	}
+	if n > 5 {
+		return false, p.errorf(start, "too many quotes at the end of the string")
+	}
+
+	b.WriteString(strings.Repeat(string(quote), n-3))
+	return true, nil
+}
+
+// trimNewline skips the newline immediately following the opening delimiter
+// of a multi-line string.
+func (p *parser) trimNewline() {
+	if !p.consume("\n") {
+		p.consume("\r\n")
+	}
+}
+
+// lineEndingBackslash skips a backslash at the end of a line along with all
+// the whitespace and newlines that follow it.
+func (p *parser) lineEndingBackslash() bool {
+	i := p.pos + 1
+	for i < len(p.data) && (p.data[i] == ' ' || p.data[i] == '\t') {
+		i++
+	}
+	if !strings.HasPrefix(p.data[i:], "\n") && !strings.HasPrefix(p.data[i:], "\r\n") {
+		return false
+	}
+
+	for i < len(p.data) && strings.ContainsRune(" \t\r\n", rune(p.data[i])) {
+		i++
+	}
+	p.pos = i
+	return true
+}
+
+var escapes = map[byte]string{
+	'b':  "\b",
+	't':  "\t",
+	'n':  "\n",
+	'f':  "\f",
+	'r':  "\r",
+	'e':  "\x1b",
+	'"':  "\"",
+	'\\': "\\",
+}
+
+// escape reads an escape sequence.
+func (p *parser) escape(b *strings.Builder) error {
+	start := p.pos
+	p.pos++
+	if p.eof() {
+		return p.errorf(start, "unterminated string")
+	}
+
+	c := p.peek()
+	p.pos++
+	if s, found := escapes[c]; found {
+		b.WriteString(s)
+		return nil
+	}
+
+	size := 0
+	switch c {
+	case 'u':
+		size = 4
+	case 'U':
+		size = 8
+	default:
+		return p.errorf(start, "invalid escape sequence '\\%c'", c)
+	}
+
+	if p.pos+size > len(p.data) {
+		return p.errorf(start, "invalid unicode escape sequence")
+	}
+	u, err := strconv.ParseUint(p.data[p.pos:p.pos+size], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(u)) {
+		return p.errorf(start, "invalid unicode escape sequence")
+	}
+
+	p.pos += size
+	b.WriteRune(rune(u))
+	return nil
+}
+
+// -- Whitespace, comments and positions ---------------------------------------
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) peek() byte {
+	return p.data[p.pos]
+}
+
+// consume advances past the string if it is next.
+func (p *parser) consume(s string) bool {
+	if strings.HasPrefix(p.data[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+// skipSpace skips spaces and tabs.
+func (p *parser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+// skipComment skips a comment if present, leaving the newline.
+func (p *parser) skipComment() {
+	if !p.eof() && p.peek() == '#' {
+		for !p.eof() && p.peek() != '\n' && !strings.HasPrefix(p.data[p.pos:], "\r\n") {
+			p.pos++
+		}
+	}
+}
+
+// skipBlank skips whitespace, newlines and comments, which are allowed
+// between the values of arrays and inline tables.
+func (p *parser) skipBlank() error {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if p.consume("\n") || p.consume("\r\n") {
+			continue
+		}
+		if !p.eof() && p.peek() == '\r' {
+			return p.errorf(p.pos, "invalid carriage return")
+		}
+		return nil
+	}
+}
+
+// endOfLine consumes the remainder of the line, which may only contain
+// whitespace and a comment.
+func (p *parser) endOfLine() error {
+	p.skipSpace()
+	p.skipComment()
+	if p.eof() || p.consume("\n") || p.consume("\r\n") {
+		return nil
+	}
+	return p.errorf(p.pos, "expected the end of the line")
+}
+
+// origin converts the offset into the line and column.  Both start at 1 and
+// the column is counted in characters.
+func (p *parser) origin(offset int) meta.Origin {
+	if p.lines == nil {
+		p.lines = []int{0}
+		for i := 0; i < len(p.data); i++ {
+			if p.data[i] == '\n' {
+				p.lines = append(p.lines, i+1)
+			}
+		}
+	}
+
+	offset = min(max(offset, 0), len(p.data))
+	line := sort.Search(len(p.lines), func(i int) bool {
+		return p.lines[i] > offset
+	}) - 1
+
+	return meta.Origin{
+		File: p.file,
+		Line: line + 1,
+		Col:  utf8.RuneCountInString(p.data[p.lines[line]:offset]) + 1,
+	}
+}
+
+func (p *parser) errorf(offset int, format string, a ...any) error {
+	return fmt.Errorf("%w: %s: %s", ErrSyntax, p.origin(offset), fmt.Sprintf(format, a...))
+}
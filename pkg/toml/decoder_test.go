@@ -0,0 +1,341 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package toml
+
+import (
+	"math"
+	"testing"
+	"testing/fstest"
+
+	"github.com/goschtalt/goschtalt"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(line, col int) []meta.Origin {
+	return []meta.Origin{{File: "file.toml", Line: line, Col: col}}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          string
+		expected    meta.Object
+		expectedErr string
+	}{
+		{
+			description: "an empty document",
+			in:          "# Only a comment.\n\n",
+			expected:    meta.Object{},
+		}, {
+			description: "simple values",
+			in: `title = "example" # A comment.
+port = 8080
+neg = -1_000
+hex = 0xff
+oct = 0o17
+bin = 0b101
+ratio = 0.5
+exp = 5e+2
+on = true
+off = false
+date = 1979-05-27
+when = 1979-05-27 07:32:00Z
+local = 07:32:00.5
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"title": {Origins: at(1, 1), Value: "example"},
+					"port":  {Origins: at(2, 1), Value: int64(8080)},
+					"neg":   {Origins: at(3, 1), Value: int64(-1000)},
+					"hex":   {Origins: at(4, 1), Value: int64(255)},
+					"oct":   {Origins: at(5, 1), Value: int64(15)},
+					"bin":   {Origins: at(6, 1), Value: int64(5)},
+					"ratio": {Origins: at(7, 1), Value: 0.5},
+					"exp":   {Origins: at(8, 1), Value: 500.0},
+					"on":    {Origins: at(9, 1), Value: true},
+					"off":   {Origins: at(10, 1), Value: false},
+					"date":  {Origins: at(11, 1), Value: "1979-05-27"},
+					"when":  {Origins: at(12, 1), Value: "1979-05-27 07:32:00Z"},
+					"local": {Origins: at(13, 1), Value: "07:32:00.5"},
+				},
+			},
+		}, {
+			description: "strings",
+			in: `basic = "a\tb \"q\" \u00e9"
+literal = 'C:\path'
+multi = """
+one
+two \
+    three"""
+quotes = """a""""
+raw = '''
+it's'''
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"basic":   {Origins: at(1, 1), Value: "a\tb \"q\" é"},
+					"literal": {Origins: at(2, 1), Value: `C:\path`},
+					"multi":   {Origins: at(3, 1), Value: "one\ntwo three"},
+					"quotes":  {Origins: at(7, 1), Value: `a"`},
+					"raw":     {Origins: at(8, 1), Value: "it's"},
+				},
+			},
+		}, {
+			description: "tables, dotted keys and instructions",
+			in: `[server]
+host.name = "example.com"
+
+["database((secret))"]
+password = "hunter2"
+
+[server.tls]
+"ciphers((append))" = [
+  "a", # The first.
+  "b",
+]
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"server": {
+						Origins: at(1, 2),
+						Map: map[string]meta.Object{
+							"host": {
+								Origins: at(2, 1),
+								Map: map[string]meta.Object{
+									"name": {Origins: at(2, 6), Value: "example.com"},
+								},
+							},
+							"tls": {
+								Origins: at(7, 9),
+								Map: map[string]meta.Object{
+									"ciphers((append))": {
+										Origins: at(8, 1),
+										Array: []meta.Object{
+											{Origins: at(9, 3), Value: "a"},
+											{Origins: at(10, 3), Value: "b"},
+										},
+									},
+								},
+							},
+						},
+					},
+					"database((secret))": {
+						Origins: at(4, 2),
+						Map: map[string]meta.Object{
+							"password": {Origins: at(5, 1), Value: "hunter2"},
+						},
+					},
+				},
+			},
+		}, {
+			description: "arrays of tables and inline tables",
+			in: `[[item]]
+name = "one"
+[item.sub]
+x = 1
+
+[[item]]
+point = { x = 1, y.z = 2 }
+empty = {}
+list = []
+`,
+			expected: meta.Object{
+				Origins: at(1, 1),
+				Map: map[string]meta.Object{
+					"item": {
+						Origins: at(1, 3),
+						Array: []meta.Object{
+							{
+								Origins: at(1, 1),
+								Map: map[string]meta.Object{
+									"name": {Origins: at(2, 1), Value: "one"},
+									"sub": {
+										Origins: at(3, 7),
+										Map: map[string]meta.Object{
+											"x": {Origins: at(4, 1), Value: int64(1)},
+										},
+									},
+								},
+							}, {
+								Origins: at(6, 1),
+								Map: map[string]meta.Object{
+									"point": {
+										Origins: at(7, 1),
+										Map: map[string]meta.Object{
+											"x": {Origins: at(7, 11), Value: int64(1)},
+											"y": {
+												Origins: at(7, 18),
+												Map: map[string]meta.Object{
+													"z": {Origins: at(7, 20), Value: int64(2)},
+												},
+											},
+										},
+									},
+									"empty": {Origins: at(8, 1), Map: map[string]meta.Object{}},
+									"list":  {Origins: at(9, 1), Array: []meta.Object{}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}, {
+			description: "columns are counted in characters",
+			in:          `"ü" = "ö"; k = 1`,
+			expectedErr: "file.toml:1[10]: expected the end of the line",
+		}, {
+			description: "a duplicate key",
+			in:          "a = 1\na = 2",
+			expectedErr: "file.toml:2[1]: key 'a' is already defined",
+		}, {
+			description: "a duplicate table",
+			in:          "[a]\n[a]",
+			expectedErr: "file.toml:2[2]: key 'a' is already defined",
+		}, {
+			description: "a table defined by dotted keys",
+			in:          "a.b = 1\n[a]",
+			expectedErr: "key 'a' is already defined",
+		}, {
+			description: "extending an inline table",
+			in:          "a = {}\na.b = 1",
+			expectedErr: "key 'a.b' is already defined",
+		}, {
+			description: "a table header for a value",
+			in:          "a = 1\n[a.b]",
+			expectedErr: "key 'a.b' is already defined as a value",
+		}, {
+			description: "an array of tables for a table",
+			in:          "[a]\n[[a]]",
+			expectedErr: "key 'a' is already defined",
+		}, {
+			description: "a missing value",
+			in:          "a = ",
+			expectedErr: "expected a value",
+		}, {
+			description: "a missing key",
+			in:          "= 1",
+			expectedErr: "expected a key",
+		}, {
+			description: "a missing equals",
+			in:          "a 1",
+			expectedErr: "expected '=' after the key",
+		}, {
+			description: "an invalid value",
+			in:          "a = nope",
+			expectedErr: "invalid value 'nope'",
+		}, {
+			description: "an invalid number",
+			in:          "a = 01",
+			expectedErr: "invalid value '01'",
+		}, {
+			description: "a number that is too large",
+			in:          "a = 9223372036854775808",
+			expectedErr: "out of range",
+		}, {
+			description: "an unterminated string",
+			in:          "a = \"abc\nb = 1",
+			expectedErr: "unterminated string",
+		}, {
+			description: "an unterminated array",
+			in:          "a = [1, 2",
+			expectedErr: "unterminated array",
+		}, {
+			description: "an invalid escape",
+			in:          `a = "\q"`,
+			expectedErr: `invalid escape sequence '\q'`,
+		}, {
+			description: "an invalid unicode escape",
+			in:          `a = "\uZZZZ"`,
+			expectedErr: "invalid unicode escape sequence",
+		}, {
+			description: "an unterminated header",
+			in:          "[a",
+			expectedErr: "expected the end of the table header",
+		}, {
+			description: "a dangling dot",
+			in:          "a.",
+			expectedErr: "expected a key",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var got meta.Object
+			ctx := decoder.Context{
+				Filename:  "file.toml",
+				Delimiter: ".",
+			}
+			err := Decoder{}.Decode(ctx, []byte(tc.in), &got)
+
+			if tc.expectedErr != "" {
+				assert.ErrorIs(err, ErrSyntax)
+				assert.ErrorContains(err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestDecodeSpecialFloats(t *testing.T) {
+	var got meta.Object
+	err := Decoder{}.Decode(decoder.Context{}, []byte("a = inf\nb = -inf\nc = nan"), &got)
+	require.NoError(t, err)
+
+	assert.True(t, math.IsInf(got.Map["a"].Value.(float64), 1))
+	assert.True(t, math.IsInf(got.Map["b"].Value.(float64), -1))
+	assert.True(t, math.IsNaN(got.Map["c"].Value.(float64)))
+}
+
+func TestDecoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"toml"}, Decoder{}.Extensions())
+}
+
+func TestEndToEnd(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	fs := fstest.MapFS{
+		"1.toml": &fstest.MapFile{
+			Data: []byte("name = \"one\"\n\n[server]\nport = 80\ntags = [\"a\"]\n"),
+		},
+		"2.toml": &fstest.MapFile{
+			Data: []byte("[server]\nport = 8080\n\"tags((replace))\" = [\"b\"]\n"),
+		},
+	}
+
+	// The decoder and encoder are registered automatically.
+	gs, err := goschtalt.New(
+		goschtalt.AddDir(fs, "."),
+		goschtalt.AutoCompile(),
+	)
+	require.NoError(err)
+
+	type config struct {
+		Name   string `goschtalt:"name"`
+		Server struct {
+			Port int      `goschtalt:"port"`
+			Tags []string `goschtalt:"tags"`
+		} `goschtalt:"server"`
+	}
+
+	got, err := goschtalt.Unmarshal[config](gs, goschtalt.Root)
+	require.NoError(err)
+	assert.Equal("one", got.Name)
+	assert.Equal(8080, got.Server.Port)
+	assert.Equal([]string{"b"}, got.Server.Tags)
+
+	out, err := gs.Marshal(goschtalt.FormatAs("toml"), goschtalt.IncludeOrigins())
+	require.NoError(err)
+	assert.Contains(string(out), "port = 8080 # 2.toml:2[1]\n")
+}
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+// Package toml provides a TOML decoder and encoder for goschtalt that only
+// depend upon the go standard library.
+//
+// The decoder supports TOML v1.0 and records the filename, line and column of
+// every key it decodes so the origins of the configuration are available when
+// explaining the configuration or reporting errors.  Since bare keys may not
+// contain parentheses, the goschtalt key instructions are specified using
+// quoted keys:
+//
+//	[servers]
+//	"list((append))" = [ "alpha", "beta" ]
+//
+//	["database((secret))"]
+//	password = "hunter2"
+//
+// The encoder produces the TOML document for normal output.  When the origins
+// are requested they are included as comments following each key.
+//
+// # Usage
+//
+// Add the following line to the import list & the decoder and encoder are
+// automatically registered for the 'toml' extension.
+//
+//	import (
+//		_ "github.com/goschtalt/goschtalt/pkg/toml"
+//	)
+//
+// Alternatively, the decoder and encoder may be registered explicitly.
+//
+//	gs, err := goschtalt.New(
+//		goschtalt.WithDecoder(toml.Decoder{}),
+//		goschtalt.WithEncoder(toml.Encoder{}),
+//	)
+package toml
+
+import "github.com/goschtalt/goschtalt"
+
+func init() {
+	goschtalt.DefaultOptions = append(goschtalt.DefaultOptions,
+		goschtalt.WithDecoder(Decoder{}),
+		goschtalt.WithEncoder(Encoder{}),
+	)
+}
@@ -0,0 +1,383 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package toml
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/goschtalt/goschtalt/pkg/encoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+var _ encoder.Encoder = (*Encoder)(nil)
+
+// The errors returned by the encoder.
+var (
+	ErrRootNotTable    = errors.New("the root of a toml document must be a table")
+	ErrUnsupportedType = errors.New("unsupported type")
+)
+
+// Encoder is a TOML encoder.
+//
+// Since TOML doesn't support null values, keys with null values are omitted.
+// Null values in arrays result in an error.
+type Encoder struct{}
+
+// Extensions returns the supported extensions.
+func (Encoder) Extensions() []string {
+	return []string{"toml"}
+}
+
+// Encode encodes the value as a TOML document.  The value must be a map.
+func (Encoder) Encode(v any) ([]byte, error) {
+	var w writer
+	if err := w.document(meta.ObjectFromRaw(v)); err != nil {
+		return nil, err
+	}
+	return []byte(w.b.String()), nil
+}
+
+// EncodeExtended encodes the configuration along with the origins of the keys
+// as comments.  Arrays are written across several lines so the origins of
+// each value can be included.  The document has the form:
+//
+//	# Origins: file.toml:1[1]
+//
+//	name = "example" # file.toml:1[1]
+//	list = [ # file.toml:2[1]
+//	  "alpha", # file.toml:2[9]
+//	]
+//
+//	[server] # file.toml:4[2]
+//	port = 8080 # file.toml:5[1]
+func (Encoder) EncodeExtended(obj meta.Object) ([]byte, error) {
+	w := writer{origins: true}
+	if err := w.document(obj); err != nil {
+		return nil, err
+	}
+	return []byte(w.b.String()), nil
+}
+
+// writer builds the TOML document.
+type writer struct {
+	b       strings.Builder
+	origins bool
+}
+
+func (w *writer) document(obj meta.Object) error {
+	if obj.Map == nil || len(obj.Array) > 0 {
+		if obj.Value == nil && len(obj.Array) == 0 {
+			return nil
+		}
+		return ErrRootNotTable
+	}
+
+	if w.origins && len(obj.Origins) > 0 {
+		w.b.WriteString("# Origins: ")
+		w.b.WriteString(obj.OriginString())
+		w.b.WriteString("\n\n")
+	}
+
+	return w.body(nil, obj)
+}
+
+// body writes the contents of a table.  The values must be written before
+// any of the tables since they belong to the most recent table header.
+func (w *writer) body(path []string, obj meta.Object) error {
+	keys := make([]string, 0, len(obj.Map))
+	for key := range obj.Map {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		val := obj.Map[key]
+		if isTable(val) || isArrayOfTables(val) {
+			continue
+		}
+		if val.Array == nil && val.Map == nil && val.Value == nil {
+			continue
+		}
+
+		w.b.WriteString(quoteKey(key))
+		w.b.WriteString(" = ")
+		if err := w.value(val); err != nil {
+			return fmt.Errorf("%w for key '%s'", err, strings.Join(append(path, key), "."))
+		}
+	}
+
+	for _, key := range keys {
+		val := obj.Map[key]
+		child := append(path[:len(path):len(path)], key)
+
+		switch {
+		case isTable(val):
+			w.header("["+headerKey(child)+"]", val)
+			if err := w.body(child, val); err != nil {
+				return err
+			}
+		case isArrayOfTables(val):
+			for _, elem := range val.Array {
+				w.header("[["+headerKey(child)+"]]", elem)
+				if err := w.body(child, elem); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *writer) header(header string, obj meta.Object) {
+	if w.b.Len() > 0 {
+		w.b.WriteString("\n")
+	}
+	w.b.WriteString(header)
+	w.comment(obj)
+	w.b.WriteString("\n")
+}
+
+// comment writes the origins of the object as a comment if requested.
+func (w *writer) comment(obj meta.Object) {
+	if w.origins && len(obj.Origins) > 0 {
+		w.b.WriteString(" # ")
+		w.b.WriteString(obj.OriginString())
+	}
+}
+
+// value writes the value of a key followed by the newline.  In extended mode
+// arrays are written across several lines so the origins of the values can
+// be included.
+func (w *writer) value(obj meta.Object) error {
+	if w.origins && len(obj.Array) > 0 {
+		w.b.WriteString("[")
+		w.comment(obj)
+		w.b.WriteString("\n")
+		for _, elem := range obj.Array {
+			w.b.WriteString("  ")
+			if err := w.inline(elem); err != nil {
+				return err
+			}
+			w.b.WriteString(",")
+			w.comment(elem)
+			w.b.WriteString("\n")
+		}
+		w.b.WriteString("]\n")
+		return nil
+	}
+
+	if err := w.inline(obj); err != nil {
+		return err
+	}
+	w.comment(obj)
+	w.b.WriteString("\n")
+	return nil
+}
+
+// inline writes the object as a single line value.
+func (w *writer) inline(obj meta.Object) error {
+	switch {
+	case len(obj.Array) > 0:
+		w.b.WriteString("[")
+		for i, elem := range obj.Array {
+			if i > 0 {
+				w.b.WriteString(", ")
+			}
+			if err := w.inline(elem); err != nil {
+				return err
+			}
+		}
+		w.b.WriteString("]")
+		return nil
+	case obj.Map != nil:
+		keys := make([]string, 0, len(obj.Map))
+		for key := range obj.Map {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		w.b.WriteString("{")
+		for i, key := range keys {
+			if i > 0 {
+				w.b.WriteString(",")
+			}
+			w.b.WriteString(" ")
+			w.b.WriteString(quoteKey(key))
+			w.b.WriteString(" = ")
+			if err := w.inline(obj.Map[key]); err != nil {
+				return err
+			}
+		}
+		if len(keys) > 0 {
+			w.b.WriteString(" ")
+		}
+		w.b.WriteString("}")
+		return nil
+	case obj.Array != nil:
+		w.b.WriteString("[]")
+		return nil
+	}
+
+	s, err := scalar(reflect.ValueOf(obj.Value))
+	if err != nil {
+		return err
+	}
+	w.b.WriteString(s)
+	return nil
+}
+
+// scalar converts the value into the TOML representation.  Values that are
+// not part of the meta.Object tree (like a []string) are converted as well.
+func scalar(v reflect.Value) (string, error) {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "", fmt.Errorf("%w: toml doesn't support null values", ErrUnsupportedType)
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339Nano), nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return quote(v.String()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return float(v.Float()), nil
+	case reflect.Slice, reflect.Array:
+		list := make([]string, v.Len())
+		for i := range list {
+			s, err := scalar(v.Index(i))
+			if err != nil {
+				return "", err
+			}
+			list[i] = s
+		}
+		return "[" + strings.Join(list, ", ") + "]", nil
+	case reflect.Map:
+		keys := v.MapKeys()
+		list := make([]string, len(keys))
+		for i, key := range keys {
+			s, err := scalar(v.MapIndex(key))
+			if err != nil {
+				return "", err
+			}
+			list[i] = quoteKey(fmt.Sprint(key.Interface())) + " = " + s
+		}
+		sort.Strings(list)
+		if len(list) == 0 {
+			return "{}", nil
+		}
+		return "{ " + strings.Join(list, ", ") + " }", nil
+	}
+
+	return "", fmt.Errorf("%w: '%s'", ErrUnsupportedType, v.Type())
+}
+
+// float returns the TOML representation of the float, which always includes
+// a decimal point or exponent.
+func float(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// isTable determines if the object is written as a table.  Empty tables are
+// written inline as '{}' instead.
+func isTable(obj meta.Object) bool {
+	return len(obj.Map) > 0 && len(obj.Array) == 0
+}
+
+// isArrayOfTables determines if the object is written as an array of tables.
+func isArrayOfTables(obj meta.Object) bool {
+	if len(obj.Array) == 0 {
+		return false
+	}
+	for _, elem := range obj.Array {
+		if !isTable(elem) {
+			return false
+		}
+	}
+	return true
+}
+
+func headerKey(path []string) string {
+	list := make([]string, len(path))
+	for i, key := range path {
+		list[i] = quoteKey(key)
+	}
+	return strings.Join(list, ".")
+}
+
+// quoteKey quotes the key unless it is a valid bare key.
+func quoteKey(key string) string {
+	if key == "" {
+		return `""`
+	}
+	for i := 0; i < len(key); i++ {
+		if !isBare(key[i]) {
+			return quote(key)
+		}
+	}
+	return key
+}
+
+// quote returns the string as a basic string.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&b, `\u%04X`, r)
+				continue
+			}
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
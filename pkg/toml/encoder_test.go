@@ -0,0 +1,200 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package toml
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		description string
+		in          any
+		expected    string
+		expectedErr error
+	}{
+		{
+			description: "nothing",
+			in:          nil,
+		}, {
+			description: "values, tables and arrays of tables",
+			in: map[string]any{
+				"name":    "example \"quoted\"\n",
+				"port":    8080,
+				"ratio":   2.0,
+				"big":     1e21,
+				"nan":     math.NaN(),
+				"inf":     math.Inf(-1),
+				"on":      true,
+				"when":    time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+				"missing": nil,
+				"list":    []any{1, "two", []any{3}, map[string]any{"a": 1}},
+				"strs":    []string{"a", "b"},
+				"empty":   []any{},
+				"a.b":     "dotted",
+				"server": map[string]any{
+					"host": "example.com",
+					"tls": map[string]any{
+						"on": false,
+					},
+				},
+				"items": []any{
+					map[string]any{"id": 1},
+					map[string]any{"id": 2, "tags": map[string]int{"x": 1}},
+				},
+				"nothing": map[string]any{},
+			},
+			expected: `"a.b" = "dotted"
+big = 1e+21
+empty = []
+inf = -inf
+list = [1, "two", [3], { a = 1 }]
+name = "example \"quoted\"\n"
+nan = nan
+nothing = {}
+on = true
+port = 8080
+ratio = 2.0
+strs = ["a", "b"]
+when = 1979-05-27T07:32:00Z
+
+[[items]]
+id = 1
+
+[[items]]
+id = 2
+tags = { x = 1 }
+
+[server]
+host = "example.com"
+
+[server.tls]
+on = false
+`,
+		}, {
+			description: "the root isn't a table",
+			in:          []any{1},
+			expectedErr: ErrRootNotTable,
+		}, {
+			description: "an unsupported type",
+			in:          map[string]any{"f": func() {}},
+			expectedErr: ErrUnsupportedType,
+		}, {
+			description: "a null value in an array",
+			in:          map[string]any{"list": []any{nil}},
+			expectedErr: ErrUnsupportedType,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			got, err := Encoder{}.Encode(tc.in)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, string(got))
+		})
+	}
+}
+
+func TestEncodeEmpty(t *testing.T) {
+	in := map[string]any{
+		"table": map[string]any{},
+		"list":  []any{},
+		"list_of_tables": []any{
+			map[string]any{},
+		},
+		"server": map[string]any{
+			"tls":   map[string]any{},
+			"hosts": []any{},
+		},
+	}
+
+	got, err := Encoder{}.Encode(in)
+	require.NoError(t, err)
+	assert.Equal(t, `list = []
+list_of_tables = [{}]
+table = {}
+
+[server]
+hosts = []
+tls = {}
+`, string(got))
+
+	// The document can be decoded again and the empty tables and arrays are
+	// still tables and arrays.
+	var obj meta.Object
+	err = Decoder{}.Decode(decoder.Context{}, got, &obj)
+	require.NoError(t, err)
+
+	server := obj.Map["server"]
+	for _, table := range []meta.Object{obj.Map["table"], obj.Map["list_of_tables"].Array[0], server.Map["tls"]} {
+		assert.NotNil(t, table.Map)
+		assert.Empty(t, table.Map)
+	}
+	for _, list := range []meta.Object{obj.Map["list"], server.Map["hosts"]} {
+		assert.NotNil(t, list.Array)
+		assert.Empty(t, list.Array)
+	}
+}
+
+func TestEncodeExtended(t *testing.T) {
+	origin := func(line int) []meta.Origin {
+		return []meta.Origin{{File: "a.toml", Line: line, Col: 1}}
+	}
+
+	in := meta.Object{
+		Origins: origin(1),
+		Map: map[string]meta.Object{
+			"name((secret))": {Origins: origin(2), Value: "example"},
+			"list": {
+				Origins: origin(3),
+				Array: []meta.Object{
+					{Origins: origin(4), Value: "a"},
+					{Value: "b"},
+				},
+			},
+			"server": {
+				Origins: origin(5),
+				Map: map[string]meta.Object{
+					"port": {Origins: origin(6), Value: int64(80)},
+				},
+			},
+		},
+	}
+
+	got, err := Encoder{}.EncodeExtended(in)
+	require.NoError(t, err)
+	assert.Equal(t, `# Origins: a.toml:1[1]
+
+list = [ # a.toml:3[1]
+  "a", # a.toml:4[1]
+  "b",
+]
+"name((secret))" = "example" # a.toml:2[1]
+
+[server] # a.toml:5[1]
+port = 80 # a.toml:6[1]
+`, string(got))
+
+	// The document can be decoded again.
+	var obj meta.Object
+	err = Decoder{}.Decode(decoder.Context{}, got, &obj)
+	require.NoError(t, err)
+	assert.Equal(t, in.ToRaw(), obj.ToRaw())
+}
+
+func TestEncoderExtensions(t *testing.T) {
+	assert.Equal(t, []string{"toml"}, Encoder{}.Extensions())
+}
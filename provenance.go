@@ -0,0 +1,189 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// ProvenanceRecord describes a single step in how the value found at a key
+// came to be.
+type ProvenanceRecord struct {
+	// Record is the name of the record that performed the step.  Steps
+	// performed by variable expansion have an empty record name.
+	Record string
+
+	// Default is true if the record was marked as a 'default' record.
+	Default bool
+
+	// Key is the key that was altered.  This may be the requested key, a
+	// parent of the requested key (replacing a whole map, for example) or a
+	// child of the requested key.  An empty key is the root of the tree.
+	Key string
+
+	// Action is what happened.  One of the meta.Action constants
+	// ([meta.ActionSet], [meta.ActionReplace], [meta.ActionAppend],
+	// [meta.ActionPrepend], [meta.ActionKeep], [meta.ActionClear] or
+	// [meta.ActionExpand]).
+	Action string
+
+	// Command is the merge command specified in the key (like 'append' or
+	// 'keep') if one was specified.
+	Command string
+
+	// Origins are the origins of the value at the key after the step.
+	Origins []meta.Origin
+}
+
+// provenanceEvent is a merge event and the record that caused it.
+type provenanceEvent struct {
+	record    string
+	isDefault bool
+	event     meta.MergeEvent
+}
+
+// Provenance returns the ordered history of how the value at the key came to
+// be during the most recent compilation.  Each record that set, replaced,
+// appended to, prepended to, kept or cleared the key (or a parent or child of
+// the key) is included along with the merge command used.  Any changes made
+// by variable expansion are listed last.
+//
+// A key that was never set results in an empty list.  The history is only
+// collected if the [TrackProvenance] option is enabled, otherwise
+// ErrNotApplicable is returned.
+//
+// To examine the entire configuration tree, use goschtalt.Root [Root] instead
+// of "" for more clarity.
+func (c *Config) Provenance(key string) ([]ProvenanceRecord, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.compiledAt.Equal(time.Time{}) {
+		return nil, ErrNotCompiled
+	}
+
+	if !c.opts.trackProvenance {
+		return nil, fmt.Errorf("%w: TrackProvenance() is not enabled", ErrNotApplicable)
+	}
+
+	var want []string
+	if len(key) > 0 {
+		want = strings.Split(key, c.opts.keyDelimiter)
+	}
+
+	rv := []ProvenanceRecord{}
+	for _, p := range c.provenance {
+		obj, ok := provenanceMatch(want, p.event)
+		if !ok {
+			continue
+		}
+
+		rv = append(rv, ProvenanceRecord{
+			Record:  p.record,
+			Default: p.isDefault,
+			Key:     strings.Join(p.event.Path, c.opts.keyDelimiter),
+			Action:  p.event.Action,
+			Command: p.event.Command,
+			Origins: obj.Origins,
+		})
+	}
+
+	return rv, nil
+}
+
+// provenanceMatch determines if the event is relevant to the wanted path and
+// returns the object that best describes the wanted path after the event.
+func provenanceMatch(want []string, event meta.MergeEvent) (meta.Object, bool) {
+	// The event is at or below the wanted path.
+	if hasPathPrefix(event.Path, want) {
+		return event.Result, true
+	}
+
+	// The event is unrelated.
+	if !hasPathPrefix(want, event.Path) {
+		return meta.Object{}, false
+	}
+
+	// The event is above the wanted path, so it is only relevant if it
+	// provided the wanted path or potentially removed it.
+	obj, err := event.Result.Fetch(want[len(event.Path):], "")
+	if err == nil {
+		return obj, true
+	}
+
+	switch event.Action {
+	case meta.ActionClear, meta.ActionReplace:
+		return event.Result, true
+	}
+
+	return meta.Object{}, false
+}
+
+// hasPathPrefix returns if the path starts with the prefix.
+func hasPathPrefix(path, prefix []string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// expansionEvents returns the events describing the values altered by the
+// variable expansion.
+func expansionEvents(before, after meta.Object) []provenanceEvent {
+	var rv []provenanceEvent
+	for _, d := range before.Diff(after).Changed {
+		obj, err := after.Fetch(d.Path, "")
+		if err != nil {
+			continue
+		}
+		rv = append(rv, provenanceEvent{
+			event: meta.MergeEvent{
+				Path:   d.Path,
+				Action: meta.ActionExpand,
+				Result: obj,
+			},
+		})
+	}
+	return rv
+}
+
+// explainMerges converts the events into the form used by the explanation.
+// Setting the root of the tree is reported as setting each of the top level
+// keys since that is more useful.
+func explainMerges(events []provenanceEvent, delimiter string) []ExplanationMerge {
+	rv := []ExplanationMerge{}
+	for _, p := range events {
+		e := p.event
+		if len(e.Path) == 0 && e.Action == meta.ActionSet {
+			for key := range e.Result.Map {
+				rv = append(rv, ExplanationMerge{
+					Key:    key,
+					Action: e.Action,
+				})
+			}
+			continue
+		}
+
+		rv = append(rv, ExplanationMerge{
+			Key:     strings.Join(e.Path, delimiter),
+			Action:  e.Action,
+			Command: e.Command,
+		})
+	}
+
+	sort.SliceStable(rv, func(i, j int) bool {
+		return rv[i].Key < rv[j].Key
+	})
+	return rv
+}
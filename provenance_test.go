@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvenance(t *testing.T) {
+	expander := mockExpander{
+		f: func(s string) (string, bool) {
+			if s == "who" {
+				return "world", true
+			}
+			return "", false
+		},
+	}
+
+	opts := []Option{
+		AddValue("defaults", Root, map[string]any{
+			"log": map[string]any{
+				"level": "info",
+				"file":  "out.log",
+			},
+			"list": []any{"a"},
+		}, AsDefault()),
+		AddValue("1.yml", Root, map[string]any{
+			"log": map[string]any{
+				"level": "debug",
+			},
+			"list((prepend))": []any{"b"},
+		}),
+		AddValue("2.yml", Root, map[string]any{
+			"log": map[string]any{
+				"level((keep))": "warn",
+			},
+			"hello": "${who}",
+		}),
+		AddValue("3.yml", Root, map[string]any{
+			"log((replace))": map[string]any{
+				"level": "error",
+			},
+		}),
+		Expand(expander),
+		TrackProvenance(),
+	}
+
+	tests := []struct {
+		description string
+		key         string
+		expected    []ProvenanceRecord
+		expectedErr error
+		notCompiled bool
+		disabled    bool
+	}{
+		{
+			description: "a value that changed a few times",
+			key:         "log.level",
+			expected: []ProvenanceRecord{
+				{
+					Record:  "defaults",
+					Default: true,
+					Action:  meta.ActionSet,
+					Origins: []meta.Origin{{File: "defaults"}},
+				}, {
+					Record:  "1.yml",
+					Key:     "log.level",
+					Action:  meta.ActionReplace,
+					Origins: []meta.Origin{{File: "1.yml"}},
+				}, {
+					Record:  "2.yml",
+					Key:     "log.level",
+					Action:  meta.ActionKeep,
+					Command: "keep",
+					Origins: []meta.Origin{{File: "1.yml"}},
+				}, {
+					Record:  "3.yml",
+					Key:     "log",
+					Action:  meta.ActionReplace,
+					Command: "replace",
+					Origins: []meta.Origin{{File: "3.yml"}},
+				},
+			},
+		}, {
+			description: "a value that was removed",
+			key:         "log.file",
+			expected: []ProvenanceRecord{
+				{
+					Record:  "defaults",
+					Default: true,
+					Action:  meta.ActionSet,
+					Origins: []meta.Origin{{File: "defaults"}},
+				}, {
+					Record:  "3.yml",
+					Key:     "log",
+					Action:  meta.ActionReplace,
+					Command: "replace",
+					Origins: []meta.Origin{{File: "3.yml"}},
+				},
+			},
+		}, {
+			description: "an array",
+			key:         "list",
+			expected: []ProvenanceRecord{
+				{
+					Record:  "defaults",
+					Default: true,
+					Action:  meta.ActionSet,
+					Origins: []meta.Origin{{File: "defaults"}},
+				}, {
+					Record:  "1.yml",
+					Key:     "list",
+					Action:  meta.ActionPrepend,
+					Command: "prepend",
+					Origins: []meta.Origin{{File: "1.yml"}, {File: "defaults"}},
+				},
+			},
+		}, {
+			description: "an expanded value",
+			key:         "hello",
+			expected: []ProvenanceRecord{
+				{
+					Record:  "2.yml",
+					Key:     "hello",
+					Action:  meta.ActionSet,
+					Origins: []meta.Origin{{File: "2.yml"}},
+				}, {
+					Key:     "hello",
+					Action:  meta.ActionExpand,
+					Origins: []meta.Origin{{File: "2.yml"}, {}},
+				},
+			},
+		}, {
+			description: "a key that was never set",
+			key:         "missing",
+			expected:    []ProvenanceRecord{},
+		}, {
+			description: "not compiled",
+			key:         "log",
+			notCompiled: true,
+			expectedErr: ErrNotCompiled,
+		}, {
+			description: "not tracked",
+			key:         "log",
+			disabled:    true,
+			expectedErr: ErrNotApplicable,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(append(opts,
+				AutoCompile(!tc.notCompiled),
+				TrackProvenance(!tc.disabled))...)
+			require.NoError(err)
+
+			got, err := cfg.Provenance(tc.key)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.Nil(got)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.expected, got)
+		})
+	}
+}
+
+func TestProvenanceClear(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg, err := New(
+		AddValue("1.yml", Root, map[string]any{"a": "b"}),
+		AddValue("2.yml", Root, map[string]any{"ignored((clear))": ""}),
+		TrackProvenance(),
+	)
+	require.NoError(err)
+
+	got, err := cfg.Provenance("a")
+	require.NoError(err)
+	require.Len(got, 2)
+	assert.Equal(meta.ActionSet, got[0].Action)
+	assert.Equal(meta.ActionClear, got[1].Action)
+	assert.Equal("2.yml", got[1].Record)
+	assert.Equal([]meta.Origin{{File: "2.yml"}}, got[1].Origins)
+
+	all, err := cfg.Provenance(Root)
+	require.NoError(err)
+	assert.Equal(got, all)
+}
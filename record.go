@@ -11,10 +11,20 @@ import (
 // record is the basic unit needed to define a configuration and it's name.
 // With this information all the records can be decoded.
 type record struct {
-	name string
-	val  *value
-	buf  *buffer
-	tree meta.Object
+	name   string
+	val    *value
+	buf    *buffer
+	env    *env
+	flags  *flags
+	remote *remote
+	tree   meta.Object
+
+	// includes are the trees of the files included by the record, merged in
+	// order before the tree.
+	includes []meta.Object
+
+	// overrides are applied after the tree is merged.
+	overrides *overrides
 }
 
 // fetch normalizes the calls to the val or encoded types of records.
@@ -35,5 +45,52 @@ func (rec *record) fetch(delimiter string, u Unmarshaler, decoders *codecRegistr
 		rec.tree = tree
 	}
 
+	if rec.flags != nil {
+		tree, err := rec.flags.toTree(delimiter)
+		if err != nil {
+			return err
+		}
+		rec.tree = tree
+	}
+
+	if rec.remote != nil {
+		tree, err := rec.remote.toTree(delimiter, decoders)
+		if err != nil {
+			return err
+		}
+		rec.tree = tree
+	}
+
+	if rec.env != nil {
+		tree, err := rec.env.toTree()
+		if err != nil {
+			return err
+		}
+		rec.tree = tree
+	}
+
 	return nil
 }
+
+// merge merges the included files and the record onto the tree and then
+// applies any overrides.
+func (rec *record) merge(tree meta.Object, delimiter string, reporter func(meta.MergeEvent)) (meta.Object, error) {
+	for _, include := range rec.includes {
+		var err error
+		tree, err = tree.MergeWithReporter(include, reporter)
+		if err != nil {
+			return meta.Object{}, err
+		}
+	}
+
+	tree, err := tree.MergeWithReporter(rec.tree, reporter)
+	if err != nil {
+		return meta.Object{}, err
+	}
+
+	if rec.overrides != nil {
+		return rec.overrides.applyTo(tree, delimiter, reporter)
+	}
+
+	return tree, nil
+}
@@ -0,0 +1,337 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goschtalt/goschtalt/internal/strs"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// The validation rules that may be specified in the struct tag after the
+// field name.  See [Config.Unmarshal] for details.
+const (
+	ruleRequired = "required"
+	ruleNonzero  = "nonzero"
+	ruleMin      = "min"
+	ruleMax      = "max"
+	ruleOneOf    = "oneof"
+	rulePattern  = "pattern"
+)
+
+// rule is a single validation rule found in a struct tag.
+type rule struct {
+	name string
+	arg  string
+}
+
+func (r rule) String() string {
+	if r.arg == "" {
+		return r.name
+	}
+	return r.name + "=" + r.arg
+}
+
+// parseRules returns the validation rules found in the tag options.  Options
+// that are not validation rules (like 'squash') are ignored.  Everything after
+// 'pattern=' is the pattern so it may contain commas.
+func parseRules(opts []string) []rule {
+	var rv []rule
+	for i, opt := range opts {
+		name, arg, _ := strings.Cut(opt, "=")
+		switch name {
+		case ruleRequired, ruleNonzero, ruleMin, ruleMax, ruleOneOf:
+			rv = append(rv, rule{name: name, arg: arg})
+		case rulePattern:
+			arg = strings.Join(append([]string{arg}, opts[i+1:]...), ",")
+			return append(rv, rule{name: name, arg: arg})
+		}
+	}
+	return rv
+}
+
+// ruleChecker walks a decoded value along with the configuration tree it was
+// decoded from and checks the validation rules found in the struct tags.
+type ruleChecker struct {
+	options   unmarshalOptions
+	delimiter string
+	patterns  map[string]*regexp.Regexp
+	errs      []error
+}
+
+// checkRules checks the validation rules found in the struct tags of the
+// result against the values decoded from the tree.  All the violations found
+// are joined together.
+func checkRules(result any, tree meta.Object, path []string, delimiter string, options unmarshalOptions) error {
+	rc := ruleChecker{
+		options:   options,
+		delimiter: delimiter,
+		patterns:  make(map[string]*regexp.Regexp),
+	}
+
+	rc.check(reflect.ValueOf(result), tree, path)
+
+	return errors.Join(rc.errs...)
+}
+
+func (rc *ruleChecker) check(v reflect.Value, obj meta.Object, path []string) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		rc.checkStruct(v, obj, path)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			var child meta.Object
+			if i < len(obj.Array) {
+				child = obj.Array[i]
+			}
+			rc.check(v.Index(i), child, strs.Append(path, strconv.Itoa(i)))
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		for _, key := range keys {
+			name := fmt.Sprint(key.Interface())
+			rc.check(v.MapIndex(key), obj.Map[name], strs.Append(path, name))
+		}
+	}
+}
+
+func (rc *ruleChecker) checkStruct(v reflect.Value, obj meta.Object, path []string) {
+	tagName := rc.options.decoder.TagName
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+
+		tag := strings.Split(sf.Tag.Get(tagName), ",")
+		name, opts := tag[0], tag[1:]
+
+		squash := rc.options.decoder.Squash && sf.Anonymous
+		var remain bool
+		for _, opt := range opts {
+			if strings.HasPrefix(opt, rulePattern+"=") {
+				break
+			}
+			switch opt {
+			case "squash":
+				squash = true
+			case "remain":
+				remain = true
+			}
+		}
+
+		if remain {
+			continue
+		}
+
+		if squash {
+			for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+				fv = fv.Elem()
+			}
+			if fv.Kind() == reflect.Struct {
+				rc.checkStruct(fv, obj, path)
+			}
+			continue
+		}
+
+		if !sf.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		key := rc.options.mapper(name)
+		if key == "-" {
+			continue
+		}
+
+		child, found := obj.Map[key]
+		childPath := strs.Append(path, key)
+
+		origins := child.Origins
+		if !found {
+			origins = obj.Origins
+		}
+
+		for _, r := range parseRules(opts) {
+			msg, err := rc.apply(r, fv, found)
+			if err != nil {
+				err = fmt.Errorf("%w: field '%s' has an invalid rule '%s': %w",
+					ErrInvalidInput, sf.Name, r, err)
+				rc.errs = append(rc.errs, err)
+				continue
+			}
+			if msg != "" {
+				rc.errs = append(rc.errs,
+					violationError(ErrRuleViolation, childPath, rc.delimiter, origins, msg))
+			}
+		}
+
+		rc.check(fv, child, childPath)
+	}
+}
+
+// apply checks the value against the rule and returns a message describing
+// the violation or an empty string if there is no violation.  Except for
+// 'required' and 'nonzero', the rules only apply to values present in the
+// configuration.
+func (rc *ruleChecker) apply(r rule, v reflect.Value, found bool) (string, error) {
+	switch r.name {
+	case ruleRequired:
+		if !found {
+			return "is required", nil
+		}
+		return "", nil
+	case ruleNonzero:
+		if v.IsZero() {
+			return "must not be the zero value", nil
+		}
+		return "", nil
+	}
+
+	if !found {
+		return "", nil
+	}
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", nil
+		}
+		v = v.Elem()
+	}
+
+	switch r.name {
+	case ruleMin, ruleMax:
+		order, length, err := compare(v, r.arg)
+		if err != nil {
+			return "", err
+		}
+
+		what := "must be"
+		if length {
+			what = "must have a length"
+		}
+		if r.name == ruleMin && order < 0 {
+			return fmt.Sprintf("%s of at least %s", what, r.arg), nil
+		}
+		if r.name == ruleMax && order > 0 {
+			return fmt.Sprintf("%s of at most %s", what, r.arg), nil
+		}
+	case ruleOneOf:
+		allowed := strings.Fields(r.arg)
+		if len(allowed) == 0 {
+			return "", errors.New("no values are allowed")
+		}
+		got := fmt.Sprint(v.Interface())
+		for _, item := range allowed {
+			if got == item {
+				return "", nil
+			}
+		}
+		return fmt.Sprintf("must be one of '%s'", strings.Join(allowed, "', '")), nil
+	case rulePattern:
+		if v.Kind() != reflect.String {
+			return "", fmt.Errorf("unsupported type '%s'", v.Type())
+		}
+		re, err := rc.pattern(r.arg)
+		if err != nil {
+			return "", err
+		}
+		if !re.MatchString(v.String()) {
+			return fmt.Sprintf("must match the pattern '%s'", r.arg), nil
+		}
+	}
+
+	return "", nil
+}
+
+// pattern returns the compiled pattern, compiling it only once.
+func (rc *ruleChecker) pattern(p string) (*regexp.Regexp, error) {
+	if re, found := rc.patterns[p]; found {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(p)
+	if err != nil {
+		return nil, err
+	}
+	rc.patterns[p] = re
+	return re, nil
+}
+
+// compare compares the value to the limit and returns -1, 0 or 1 as the value
+// is less than, equal to or greater than the limit.  Strings, slices, arrays
+// and maps are compared by their length, which is indicated by the returned
+// boolean.  Durations may use the limits like '1s' or '5m'.
+func compare(v reflect.Value, limit string) (int, bool, error) {
+	switch v.Kind() {
+	case reflect.String:
+		n, err := strconv.Atoi(limit)
+		return cmp.Compare(utf8.RuneCountInString(v.String()), n), true, err
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, err := strconv.Atoi(limit)
+		return cmp.Compare(v.Len(), n), true, err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.Type() == reflect.TypeOf(time.Duration(0)) {
+			d, err := time.ParseDuration(limit)
+			return cmp.Compare(v.Int(), int64(d)), false, err
+		}
+		n, err := strconv.ParseInt(limit, 0, 64)
+		return cmp.Compare(v.Int(), n), false, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(limit, 0, 64)
+		return cmp.Compare(v.Uint(), n), false, err
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(limit, 64)
+		return cmp.Compare(v.Float(), n), false, err
+	}
+
+	return 0, false, fmt.Errorf("unsupported type '%s'", v.Type())
+}
+
+// violationError describes a value in the configuration tree that isn't
+// valid, including where the value came from.
+func violationError(kind error, path []string, delimiter string, origins []meta.Origin, msg string) error {
+	key := strings.Join(path, delimiter)
+	return fmt.Errorf("%w: '%s' (%s) %s", kind, keyString(key), originsString(origins), msg)
+}
+
+// keyString returns the key in a form suitable for messages.
+func keyString(key string) string {
+	if key == "" {
+		return "<root>"
+	}
+	return key
+}
+
+// originsString returns the origins in a form suitable for messages.
+func originsString(origins []meta.Origin) string {
+	s := meta.Object{Origins: origins}.OriginString()
+	if s == "" {
+		return "unknown"
+	}
+	return s
+}
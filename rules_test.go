@@ -0,0 +1,248 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rulesBase struct {
+	ID string `goschtalt:"id,required"`
+}
+
+type rulesBackend struct {
+	Host string `goschtalt:"host,required,pattern=^[a-z.]+$"`
+}
+
+type rulesConfig struct {
+	rulesBase `goschtalt:",squash"`
+
+	Port     int                     `goschtalt:"port,min=1,max=65535"`
+	Workers  uint                    `goschtalt:"workers,max=8"`
+	Ratio    float64                 `goschtalt:"ratio,min=0.5"`
+	Timeout  time.Duration           `goschtalt:"timeout,min=1s,max=1m"`
+	Name     string                  `goschtalt:"name,nonzero,min=2,max=4"`
+	Mode     string                  `goschtalt:"mode,oneof=fast slow"`
+	Zone     string                  `goschtalt:"zone,pattern=^[a-z]{1,3}$"`
+	Tags     []string                `goschtalt:"tags,max=2"`
+	Backends []rulesBackend          `goschtalt:"backends"`
+	Named    map[string]rulesBackend `goschtalt:"named"`
+	Ptr      *int                    `goschtalt:"ptr,max=5"`
+	Extra    map[string]any          `goschtalt:",remain"`
+}
+
+func TestRules(t *testing.T) {
+	valid := map[string]any{
+		"id":       "abc",
+		"port":     80,
+		"workers":  2,
+		"ratio":    0.75,
+		"timeout":  "10s",
+		"name":     "web",
+		"mode":     "fast",
+		"tags":     []any{"a"},
+		"backends": []any{map[string]any{"host": "example.com"}},
+		"named":    map[string]any{"one": map[string]any{"host": "example.org"}},
+		"ptr":      4,
+	}
+
+	durationAdapter := AdaptFromCfg(AdapterFromCfgFunc(
+		func(from, to reflect.Value) (any, error) {
+			if from.Kind() != reflect.String || to.Type() != reflect.TypeOf(time.Duration(0)) {
+				return nil, ErrNotApplicable
+			}
+			return time.ParseDuration(from.String())
+		}), "duration")
+
+	tests := []struct {
+		description string
+		data        map[string]any
+		opts        []Option
+		key         string
+		uopts       []UnmarshalOption
+		expectedErr error
+		expectedStr []string
+	}{
+		{
+			description: "everything is valid",
+		}, {
+			description: "the rules only apply to present values",
+			opts:        []Option{},
+			data: map[string]any{
+				"id":   "abc",
+				"name": "web",
+			},
+		}, {
+			description: "an optional missing key isn't checked",
+			key:         "missing",
+			uopts:       []UnmarshalOption{Optional()},
+		}, {
+			description: "numbers and durations",
+			opts: []Option{
+				AddValue("user", "",
+					map[string]any{
+						"port":    0,
+						"workers": 9,
+						"ratio":   0.25,
+						"timeout": "2m",
+						"ptr":     6,
+					},
+				),
+			},
+			expectedErr: ErrRuleViolation,
+			expectedStr: []string{
+				"validation rule violation: 'port' (user) must be of at least 1",
+				"validation rule violation: 'workers' (user) must be of at most 8",
+				"validation rule violation: 'ratio' (user) must be of at least 0.5",
+				"validation rule violation: 'timeout' (user) must be of at most 1m",
+				"validation rule violation: 'ptr' (user) must be of at most 5",
+			},
+		}, {
+			description: "lengths, enumerations and patterns",
+			opts: []Option{
+				AddValue("user", "",
+					map[string]any{
+						"name": "a",
+						"mode": "medium",
+						"zone": "abcd",
+						"tags": []any{"a", "b", "c"},
+					},
+				),
+				AddValue("user2", "named.one.host", "UPPER"),
+			},
+			expectedErr: ErrRuleViolation,
+			expectedStr: []string{
+				"validation rule violation: 'name' (user) must have a length of at least 2",
+				"validation rule violation: 'mode' (user) must be one of 'fast', 'slow'",
+				"validation rule violation: 'zone' (user) must match the pattern '^[a-z]{1,3}$'",
+				"validation rule violation: 'tags' (record, user) must have a length of at most 2",
+				"validation rule violation: 'named.one.host' (user2) must match the pattern '^[a-z.]+$'",
+			},
+		}, {
+			description: "required and nonzero",
+			data: map[string]any{
+				"backends": []any{map[string]any{"other": "x"}},
+			},
+			expectedErr: ErrRuleViolation,
+			expectedStr: []string{
+				"validation rule violation: 'id' (record) is required",
+				"validation rule violation: 'name' (record) must not be the zero value",
+				"validation rule violation: 'backends.0.host' (record) is required",
+			},
+		}, {
+			description: "the key is included in the path",
+			opts: []Option{
+				AddValue("user", "sub", map[string]any{"port": 0}),
+			},
+			key:         "sub",
+			expectedErr: ErrRuleViolation,
+			expectedStr: []string{
+				"validation rule violation: 'sub.port' (user) must be of at least 1",
+				"validation rule violation: 'sub.id' (user) is required",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			data := valid
+			if tc.data != nil {
+				data = tc.data
+			}
+
+			opts := append([]Option{
+				AddValue("record", Root, data),
+				DefaultUnmarshalOptions(durationAdapter),
+			}, tc.opts...)
+
+			cfg, err := New(opts...)
+			require.NoError(err)
+
+			_, err = Unmarshal[rulesConfig](cfg, tc.key, tc.uopts...)
+			if tc.expectedErr == nil {
+				assert.NoError(err)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+			for _, str := range tc.expectedStr {
+				assert.Contains(err.Error(), str)
+			}
+		})
+	}
+}
+
+func TestRulesInvalid(t *testing.T) {
+	tests := []struct {
+		description string
+		result      any
+	}{
+		{
+			description: "invalid min",
+			result: &struct {
+				Port int `goschtalt:"port,min=one"`
+			}{},
+		}, {
+			description: "invalid pattern",
+			result: &struct {
+				Name string `goschtalt:"name,pattern=("`
+			}{},
+		}, {
+			description: "pattern on a number",
+			result: &struct {
+				Port int `goschtalt:"port,pattern=^1"`
+			}{},
+		}, {
+			description: "empty oneof",
+			result: &struct {
+				Name string `goschtalt:"name,oneof="`
+			}{},
+		}, {
+			description: "max on an unsupported type",
+			result: &struct {
+				Flag bool `goschtalt:"flag,max=1"`
+			}{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			cfg, err := New(
+				AddValue("record", Root, map[string]any{
+					"port": 1,
+					"name": "a",
+					"flag": true,
+				}),
+			)
+			require.NoError(t, err)
+
+			err = cfg.Unmarshal(Root, tc.result)
+			assert.ErrorIs(t, err, ErrInvalidInput)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	got := parseRules([]string{"squash", "required", "min=1", "oneof=a b", "omitempty", "pattern=a=b"})
+	assert.Equal(t, []rule{
+		{name: ruleRequired},
+		{name: ruleMin, arg: "1"},
+		{name: ruleOneOf, arg: "a b"},
+		{name: rulePattern, arg: "a=b"},
+	}, got)
+	assert.Equal(t, "min=1", got[1].String())
+	assert.Equal(t, "required", got[0].String())
+
+	got = parseRules([]string{"required", "pattern=^[a-z]{1", "3}$", "squash"})
+	assert.Equal(t, []rule{
+		{name: ruleRequired},
+		{name: rulePattern, arg: "^[a-z]{1,3}$,squash"},
+	}, got)
+}
@@ -0,0 +1,395 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/jsonschema"
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/internal/structs"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// JSONSchema provides a generics based approach to producing a JSON Schema
+// document describing the configuration that unmarshals into the type T.
+//
+// See [Config.JSONSchema] for details.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [UnmarshalOption]
+//   - [UnmarshalValueOption]
+func JSONSchema[T any](c *Config, opts ...UnmarshalOption) ([]byte, error) {
+	var zero T
+	return c.JSONSchema(zero, opts...)
+}
+
+// JSONSchema produces a JSON Schema (draft 2020-12) document describing the
+// configuration that unmarshals into the type of v.  The document is useful for
+// editors to validate and autocomplete configuration files.
+//
+// The same options used by [Config.Unmarshal] are honored:
+//   - The field names are mapped to configuration keys using the [TagName],
+//     [ConfigIs], [Keymap] and other mapping options.
+//   - Fields that are converted from a string by an adapter registered via
+//     [AdaptFromCfg] (like durations and times) are described as strings.
+//   - [Strictness] determines if additional properties are allowed and if
+//     all properties are required.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [UnmarshalOption]
+//   - [UnmarshalValueOption]
+func (c *Config) JSONSchema(v any, opts ...UnmarshalOption) ([]byte, error) {
+	s, err := c.jsonSchema(reflect.TypeOf(v), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// jsonSchema produces the schema describing the type t.
+func (c *Config) jsonSchema(t reflect.Type, opts ...UnmarshalOption) (*jsonschema.Schema, error) {
+	c.mutex.Lock()
+	options, err := c.unmarshalOptions(nil, opts...)
+	c.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	// Describing the type doesn't map any configuration values, so the
+	// reporters (like the one in the Explanation) must not see the mappings.
+	options.reporters = nil
+
+	sb := schemaBuilder{
+		options: options,
+		seen:    make(map[reflect.Type]bool),
+	}
+
+	s := sb.build(t)
+	s.Schema = jsonschema.Draft
+	return s, nil
+}
+
+// schemaBuilder walks a type and produces the schema that describes it.
+type schemaBuilder struct {
+	options unmarshalOptions
+	seen    map[reflect.Type]bool
+}
+
+func (sb *schemaBuilder) build(t reflect.Type) *jsonschema.Schema {
+	if t == nil {
+		return &jsonschema.Schema{}
+	}
+
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	// Only named types are checked since the built in types are described
+	// well by their kind.
+	if t.PkgPath() != "" && sb.fromString(t) {
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeBoolean}}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeInteger}}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		zero := 0.0
+		return &jsonschema.Schema{
+			Type:    jsonschema.Types{jsonschema.TypeInteger},
+			Minimum: &zero,
+		}
+	case reflect.Float32, reflect.Float64:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeNumber}}
+	case reflect.String:
+		return &jsonschema.Schema{Type: jsonschema.Types{jsonschema.TypeString}}
+	case reflect.Slice, reflect.Array:
+		return &jsonschema.Schema{
+			Type:  jsonschema.Types{jsonschema.TypeArray},
+			Items: sb.build(t.Elem()),
+		}
+	case reflect.Map:
+		return &jsonschema.Schema{
+			Type:                 jsonschema.Types{jsonschema.TypeObject},
+			AdditionalProperties: sb.build(t.Elem()),
+		}
+	case reflect.Struct:
+		return sb.buildStruct(t)
+	}
+
+	// Interfaces and anything else can be anything.
+	return &jsonschema.Schema{}
+}
+
+func (sb *schemaBuilder) buildStruct(t reflect.Type) *jsonschema.Schema {
+	// Recursive types can't be described without references, so allow
+	// anything at the point of recursion.
+	if sb.seen[t] {
+		return &jsonschema.Schema{}
+	}
+	sb.seen[t] = true
+	defer delete(sb.seen, t)
+
+	s := jsonschema.Schema{
+		Type:       jsonschema.Types{jsonschema.TypeObject},
+		Properties: make(map[string]*jsonschema.Schema),
+	}
+
+	remain := sb.addFields(&s, t)
+
+	if sb.options.decoder.ErrorUnused && !remain {
+		s.AdditionalProperties = jsonschema.Bool(false)
+	}
+	if sb.options.decoder.ErrorUnset {
+		for key := range s.Properties {
+			s.Required = append(s.Required, key)
+		}
+		sort.Strings(s.Required)
+	}
+
+	return &s
+}
+
+// addFields adds the fields of the struct type to the schema and returns if a
+// field collects the remaining values.
+func (sb *schemaBuilder) addFields(s *jsonschema.Schema, t reflect.Type) bool {
+	tagName := sb.options.decoder.TagName
+
+	st := structs.New(reflect.New(t).Interface())
+	st.TagName = tagName
+
+	var remain bool
+	for _, field := range st.Fields() {
+		// Embedded structs may be squashed even if they are not exported.
+		if !field.IsExported() && !field.IsEmbedded() {
+			continue
+		}
+
+		sf, _ := t.FieldByName(field.Name())
+		ft := sf.Type
+		name, rest, _ := strings.Cut(field.Tag(tagName), ",")
+
+		squash := sb.options.decoder.Squash && field.IsEmbedded()
+		var isRemain bool
+		for _, opt := range strings.Split(rest, ",") {
+			switch opt {
+			case "squash":
+				squash = true
+			case "remain":
+				isRemain = true
+			}
+		}
+
+		// The field collecting the remaining values isn't a property.
+		if isRemain {
+			remain = true
+			continue
+		}
+
+		if squash {
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				remain = sb.addFields(s, ft) || remain
+			}
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name()
+		}
+
+		key := sb.options.mapper(name)
+		if key == "-" {
+			continue
+		}
+
+		s.Properties[key] = sb.build(ft)
+	}
+
+	return remain
+}
+
+// fromString determines if one of the adapters converts a string into the
+// type.  Adapters report [ErrNotApplicable] for conversions they don't
+// handle, so any other response means the adapter handles the type.
+func (sb *schemaBuilder) fromString(t reflect.Type) bool {
+	if len(sb.options.adapters) == 0 {
+		return false
+	}
+
+	probes := []string{""}
+
+	// A valid string form is available for types that can marshal
+	// themselves as text.
+	if m, ok := reflect.New(t).Interface().(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil && len(text) > 0 {
+			probes = append(probes, string(text))
+		}
+	}
+
+	to := reflect.New(t).Elem()
+	for _, probe := range probes {
+		for _, adapter := range sb.options.adapters {
+			_, err := adapter(reflect.ValueOf(probe), to)
+			if !errors.Is(err, ErrNotApplicable) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// -- Schema validation follows ------------------------------------------------
+
+// ValidateSchema provides a way to validate the portion of the configuration
+// tree being unmarshaled against a JSON Schema document prior to decoding it.
+// Since the configuration tree is validated (instead of the resulting
+// structure) the errors include the file, line and column of the offending
+// values when the decoder provides them.
+//
+// Each violation found results in an error that wraps [ErrSchemaViolation].
+// The errors are joined together.
+//
+// Only a subset of JSON Schema is supported: the 'type', 'properties',
+// 'additionalProperties', 'required', 'items', 'minItems', 'maxItems',
+// 'minimum', 'maximum', 'minLength', 'maxLength', 'pattern' and 'enum'
+// keywords.  Other keywords are ignored.
+//
+// See also: [ValidateSchemaAt]
+//
+// # Default
+//
+// The default behavior is to not validate.
+func ValidateSchema(schema []byte) UnmarshalOption {
+	s, err := jsonschema.Parse(schema)
+	if err != nil {
+		err = fmt.Errorf("%w: invalid JSON Schema: %w", ErrInvalidInput, err)
+	}
+
+	return &validateSchemaOption{
+		schema: s,
+		err:    err,
+	}
+}
+
+type validateSchemaOption struct {
+	schema *jsonschema.Schema
+	err    error
+}
+
+func (v validateSchemaOption) unmarshalApply(opts *unmarshalOptions) error {
+	opts.schema = v.schema
+	return v.err
+}
+
+func (v validateSchemaOption) String() string {
+	return print.P("ValidateSchema", print.SubOpt())
+}
+
+// ValidateSchemaAt provides a way to validate the compiled configuration tree
+// found at the key against a JSON Schema document each time the configuration
+// is compiled.  If the configuration doesn't satisfy the schema the
+// compilation fails and the errors include the file, line and column of the
+// offending values when the decoder provides them.
+//
+// Each violation found results in an error that wraps [ErrSchemaViolation].
+// The errors are joined together.
+//
+// To validate the entire configuration tree, use goschtalt.Root [Root]
+// instead of "" for more clarity.
+//
+// See [ValidateSchema] for the supported JSON Schema keywords.
+//
+// # Default
+//
+// The default behavior is to not validate.
+func ValidateSchemaAt(key string, schema []byte) Option {
+	s, err := jsonschema.Parse(schema)
+	if err != nil {
+		err = fmt.Errorf("%w: invalid JSON Schema: %w", ErrInvalidInput, err)
+	}
+
+	return &validateSchemaAtOption{
+		check: schemaCheck{
+			key:    key,
+			schema: s,
+		},
+		err: err,
+	}
+}
+
+type validateSchemaAtOption struct {
+	check schemaCheck
+	err   error
+}
+
+func (v validateSchemaAtOption) apply(opts *options) error {
+	if v.err != nil {
+		return v.err
+	}
+	opts.schemas = append(opts.schemas, v.check)
+	return nil
+}
+
+func (_ validateSchemaAtOption) ignoreDefaults() bool { return false }
+func (v validateSchemaAtOption) String() string {
+	return print.P("ValidateSchemaAt", print.String(v.check.key))
+}
+
+// schemaCheck is a schema to validate against a portion of the tree.
+type schemaCheck struct {
+	key    string
+	schema *jsonschema.Schema
+}
+
+// validate checks the tree against the schema.
+func (s schemaCheck) validate(tree meta.Object, delimiter string) error {
+	var path []string
+	if len(s.key) > 0 {
+		path = strings.Split(s.key, delimiter)
+
+		var err error
+		tree, err = tree.Fetch(path, delimiter)
+		if err != nil {
+			tree = meta.Object{}
+		}
+	}
+
+	return schemaErrors(s.schema, tree, path, delimiter)
+}
+
+// schemaErrors validates the tree against the schema and converts any
+// violations into errors.  The prefix is the path of the tree.
+func schemaErrors(s *jsonschema.Schema, tree meta.Object, prefix []string, delimiter string) error {
+	if s == nil {
+		return nil
+	}
+
+	var errs []error
+	for _, v := range s.Validate(tree) {
+		path := append(append([]string{}, prefix...), v.Path...)
+		errs = append(errs, violationError(ErrSchemaViolation, path, delimiter, v.Origins, v.Message))
+	}
+
+	return errors.Join(errs...)
+}
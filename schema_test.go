@@ -0,0 +1,380 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaLevel int
+
+func (l schemaLevel) MarshalText() ([]byte, error) {
+	return []byte("info"), nil
+}
+
+func (l *schemaLevel) UnmarshalText(b []byte) error {
+	if string(b) != "info" {
+		return errors.New("invalid level")
+	}
+	*l = 1
+	return nil
+}
+
+// schemaTextAdapter works like the text adapter; it returns ErrNotApplicable
+// for any text that doesn't unmarshal.
+var schemaTextAdapter = AdaptFromCfg(AdapterFromCfgFunc(
+	func(from, to reflect.Value) (any, error) {
+		if from.Kind() != reflect.String || to.Type() != reflect.TypeOf(schemaLevel(0)) {
+			return nil, ErrNotApplicable
+		}
+		var l schemaLevel
+		if err := l.UnmarshalText([]byte(from.String())); err != nil {
+			return nil, ErrNotApplicable
+		}
+		return l, nil
+	}), "text")
+
+var schemaDurationAdapter = AdaptFromCfg(AdapterFromCfgFunc(
+	func(from, to reflect.Value) (any, error) {
+		if from.Kind() != reflect.String || to.Type() != reflect.TypeOf(time.Duration(0)) {
+			return nil, ErrNotApplicable
+		}
+		return time.ParseDuration(from.String())
+	}), "duration")
+
+type schemaInner struct {
+	Name    string
+	Timeout time.Duration
+}
+
+type schemaEmbedded struct {
+	Embedded bool
+}
+
+type schemaRecursive struct {
+	Name string
+	Next *schemaRecursive
+}
+
+type schemaConfig struct {
+	schemaEmbedded `goschtalt:",squash"`
+
+	FirstName string
+	Count     uint8
+	Ratio     float32
+	Level     schemaLevel
+	Tags      []string
+	Labels    map[string]int
+	Inner     *schemaInner
+	Renamed   int `goschtalt:"other"`
+	Ignored   int `goschtalt:"-"`
+	Anything  any
+	Recursive schemaRecursive
+	ignored   int
+}
+
+func TestJSONSchema(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		uopts       []UnmarshalOption
+		expected    string
+		expectedErr error
+	}{
+		{
+			description: "no options",
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"Embedded":  {"type": "boolean"},
+					"FirstName": {"type": "string"},
+					"Count":     {"type": "integer", "minimum": 0},
+					"Ratio":     {"type": "number"},
+					"Level":     {"type": "integer"},
+					"Tags":      {"type": "array", "items": {"type": "string"}},
+					"Labels":    {"type": "object", "additionalProperties": {"type": "integer"}},
+					"Inner":     {
+						"type": "object",
+						"properties": {
+							"Name":    {"type": "string"},
+							"Timeout": {"type": "integer"}
+						}
+					},
+					"other":     {"type": "integer"},
+					"Anything":  {},
+					"Recursive": {
+						"type": "object",
+						"properties": {
+							"Name": {"type": "string"},
+							"Next": {}
+						}
+					}
+				}
+			}`,
+		}, {
+			description: "casing, keymap, adapters and strictness",
+			opts: []Option{
+				ConfigIs("two_words", map[string]string{"FirstName": "given"}),
+				DefaultUnmarshalOptions(
+					schemaDurationAdapter,
+					schemaTextAdapter,
+				),
+			},
+			uopts: []UnmarshalOption{Strictness(EXACT)},
+			expected: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["anything", "count", "embedded", "given", "inner", "labels", "level", "other", "ratio", "recursive", "tags"],
+				"properties": {
+					"embedded":  {"type": "boolean"},
+					"given":     {"type": "string"},
+					"count":     {"type": "integer", "minimum": 0},
+					"ratio":     {"type": "number"},
+					"level":     {"type": "string"},
+					"tags":      {"type": "array", "items": {"type": "string"}},
+					"labels":    {"type": "object", "additionalProperties": {"type": "integer"}},
+					"inner":     {
+						"type": "object",
+						"additionalProperties": false,
+						"required": ["name", "timeout"],
+						"properties": {
+							"name":    {"type": "string"},
+							"timeout": {"type": "string"}
+						}
+					},
+					"other":     {"type": "integer"},
+					"anything":  {},
+					"recursive": {
+						"type": "object",
+						"additionalProperties": false,
+						"required": ["name", "next"],
+						"properties": {
+							"name": {"type": "string"},
+							"next": {}
+						}
+					}
+				}
+			}`,
+		}, {
+			description: "an invalid option",
+			uopts:       []UnmarshalOption{Strictness("invalid")},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(tc.opts...)
+			require.NoError(err)
+
+			got, err := JSONSchema[schemaConfig](cfg, tc.uopts...)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				assert.Nil(got)
+				return
+			}
+
+			require.NoError(err)
+			assert.JSONEq(tc.expected, string(got))
+		})
+	}
+}
+
+func TestJSONSchemaRemain(t *testing.T) {
+	type remain struct {
+		Name  string
+		Extra map[string]any `goschtalt:",remain"`
+	}
+
+	cfg, err := New()
+	require.NoError(t, err)
+
+	got, err := cfg.JSONSchema(&remain{}, Strictness(SUBSET))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"Name": {"type": "string"}
+		}
+	}`, string(got))
+}
+
+func TestJSONSchemaNil(t *testing.T) {
+	cfg, err := New()
+	require.NoError(t, err)
+
+	got, err := cfg.JSONSchema(nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"$schema": "https://json-schema.org/draft/2020-12/schema"}`, string(got))
+}
+
+func TestJSONSchemaNoReport(t *testing.T) {
+	type simple struct {
+		Name string
+	}
+
+	cfg, err := New(ConfigIs("two_words"))
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cfg.JSONSchema(&simple{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Empty(t, cfg.Explain().Keyremapping.String())
+}
+
+func TestValidateSchema(t *testing.T) {
+	fs := fstest.MapFS{
+		"conf.json": &fstest.MapFile{
+			Data: []byte(`{"server":{"port":"99999","name":"web"}}`),
+			Mode: 0755,
+		},
+	}
+
+	schema := []byte(`{
+		"type": "object",
+		"required": ["port", "host"],
+		"properties": {
+			"port": {"type": "integer", "maximum": 65535}
+		}
+	}`)
+
+	tests := []struct {
+		description string
+		key         string
+		opts        []UnmarshalOption
+		expectedErr error
+		expectedStr []string
+	}{
+		{
+			description: "valid",
+			key:         "server",
+			opts:        []UnmarshalOption{ValidateSchema([]byte(`{"required": ["name"]}`))},
+		}, {
+			description: "violations include the key and origin",
+			key:         "server",
+			opts:        []UnmarshalOption{ValidateSchema(schema)},
+			expectedErr: ErrSchemaViolation,
+			expectedStr: []string{
+				`schema violation: 'server' \(conf.json:\d+\[123\]\) missing required properties 'host'`,
+				`schema violation: 'server.port' \(conf.json:\d+\[123\]\) must be at most 65535`,
+			},
+		}, {
+			description: "an optional missing key isn't validated",
+			key:         "missing",
+			opts:        []UnmarshalOption{ValidateSchema(schema), Optional()},
+		}, {
+			description: "an invalid schema",
+			key:         "server",
+			opts:        []UnmarshalOption{ValidateSchema([]byte(`{`))},
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				AddFile(fs, "conf.json"),
+			)
+			require.NoError(err)
+
+			_, err = Unmarshal[map[string]any](cfg, tc.key, tc.opts...)
+			if tc.expectedErr == nil {
+				assert.NoError(err)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+			for _, str := range tc.expectedStr {
+				assert.Regexp(str, err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateSchemaAt(t *testing.T) {
+	tests := []struct {
+		description string
+		opts        []Option
+		expectedErr error
+		expectedStr string
+	}{
+		{
+			description: "valid",
+			opts: []Option{
+				ValidateSchemaAt("server", []byte(`{"properties": {"port": {"type": "integer"}}}`)),
+			},
+		}, {
+			description: "the value from a string only source is accepted",
+			opts: []Option{
+				AddValue("user", "server.port", "8080"),
+				ValidateSchemaAt("server", []byte(`{"properties": {"port": {"type": "integer"}}}`)),
+			},
+		}, {
+			description: "the whole tree",
+			opts: []Option{
+				ValidateSchemaAt(Root, []byte(`{"additionalProperties": false}`)),
+			},
+			expectedErr: ErrSchemaViolation,
+			expectedStr: "schema violation: 'server' (record) is not an allowed property",
+		}, {
+			description: "a missing key",
+			opts: []Option{
+				ValidateSchemaAt("missing", []byte(`{"type": "object", "required": ["name"]}`)),
+			},
+			expectedErr: ErrSchemaViolation,
+			expectedStr: "schema violation: 'missing' (unknown) expected object, found null",
+		}, {
+			description: "a type mismatch",
+			opts: []Option{
+				AddValue("user", "server.port", "http"),
+				ValidateSchemaAt("server", []byte(`{"properties": {"port": {"type": "integer"}}}`)),
+			},
+			expectedErr: ErrSchemaViolation,
+			expectedStr: "schema violation: 'server.port' (user) expected integer, found string",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			opts := append([]Option{
+				AddValue("record", "server", map[string]any{"port": 80}),
+			}, tc.opts...)
+
+			cfg, err := New(opts...)
+			if tc.expectedErr == nil {
+				assert.NoError(err)
+				assert.NotNil(cfg)
+				return
+			}
+
+			assert.ErrorIs(err, tc.expectedErr)
+			assert.Contains(err.Error(), tc.expectedStr)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+)
+
+const confDirName = "conf.d"
+
+// stdLocations are the filesystems searched by StdCfgLayout.  Each platform
+// populates the locations it supports; the rest are left nil and skipped.
+type stdLocations struct {
+	local fs.FS
+	root  fs.FS
+
+	// The non-windows locations.
+	home fs.FS
+	etc  fs.FS
+
+	// The XDG Base Directory locations, in the order they are searched.
+	configHome fs.FS
+	configDirs []fs.FS
+
+	// The drop-in directories, from the lowest to the highest precedence.
+	dropIns []fs.FS
+
+	// The windows locations.
+	localAppData fs.FS
+	appData      fs.FS
+	programData  fs.FS
+}
+
+// winStdCfgLayout is the windows version of StdCfgLayout.  It is not limited
+// to windows builds so the search order can be tested everywhere.
+func winStdCfgLayout(appName string, files []string, paths stdLocations) Option {
+	return cfgLayout("StdCfgLayout", appName, `/\`, files, paths,
+		paths.local, paths.localAppData, paths.appData, paths.programData)
+}
+
+// cfgLayout provides the options for the named layout.  The appName may not
+// contain any of the separators.  If any files are specified, only they are
+// used.  Otherwise the appName.* files and the conf.d directory are searched
+// in each of the dirs in order, skipping any that are nil.
+func cfgLayout(layout, appName, separators string, files []string, paths stdLocations, dirs ...fs.FS) Option {
+	if appName == "" {
+		return WithError(fmt.Errorf("%w: %s appName cannot be empty", ErrInvalidInput, layout))
+	}
+	if i := strings.IndexAny(appName, separators); i >= 0 {
+		return WithError(fmt.Errorf("%w: %s appName cannot contain character '%s'", ErrInvalidInput, layout, string(appName[i])))
+	}
+
+	// Prune out any empty files.
+	actualFiles := make([]string, 0, len(files))
+	for _, file := range files {
+		if file != "" {
+			actualFiles = append(actualFiles, file)
+		}
+	}
+
+	if len(actualFiles) > 0 {
+		return AddJumbledHalt(paths.root, paths.local, actualFiles...)
+	}
+
+	single := appName + ".*"
+
+	// The order of the options matters
+	opts := make([]Option, 0, 2*len(dirs))
+	for _, dir := range dirs {
+		if dir != nil {
+			opts = append(opts,
+				AddFilesHalt(dir, single),
+				AddTreeHalt(dir, confDirName),
+			)
+		}
+	}
+
+	return NamedOptions(layout, opts...)
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWinStdCfgLayout(t *testing.T) {
+	file := func(status string) *fstest.MapFile {
+		return &fstest.MapFile{
+			Data: []byte(`{"Status": "` + status + `"}`),
+			Mode: 0755,
+		}
+	}
+
+	none := fstest.MapFS{}
+
+	local := fstest.MapFS{
+		"1.json":       file("local - wanted"),
+		"dir/2.json":   file("local - dir wanted"),
+		"example.json": file("local - default name wanted"),
+	}
+
+	localTree := fstest.MapFS{
+		"conf.d/2.json": file("local - tree wanted"),
+	}
+
+	localAppData := fstest.MapFS{
+		"example.json":  file("local app data - wanted"),
+		"conf.d/2.json": file("local app data - tree not wanted"),
+	}
+
+	localAppDataTree := fstest.MapFS{
+		"conf.d/2.json": file("local app data - tree wanted"),
+	}
+
+	appData := fstest.MapFS{
+		"example.json": file("app data - wanted"),
+	}
+
+	appDataTree := fstest.MapFS{
+		"conf.d/2.json": file("app data - tree wanted"),
+	}
+
+	programData := fstest.MapFS{
+		"example.json": file("program data - wanted"),
+	}
+
+	programDataTree := fstest.MapFS{
+		"conf.d/2.json": file("program data - tree wanted"),
+	}
+
+	never := fstest.MapFS{
+		"example.json": file("never wanted"),
+	}
+
+	type st struct {
+		Status string
+	}
+
+	tests := []struct {
+		description string
+		appName     string
+		files       []string
+		locations   stdLocations
+		expect      string
+		records     []string
+		expectedErr error
+	}{
+		{
+			description: "local - a file in the list",
+			appName:     "example",
+			files:       []string{"./1.json"},
+			locations: stdLocations{
+				root:         none,
+				local:        local,
+				localAppData: localAppData,
+			},
+			expect:  "local - wanted",
+			records: []string{"1.json"},
+		}, {
+			description: "local - default file",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        local,
+				localAppData: localAppData,
+			},
+			expect:  "local - default name wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "local - default tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        localTree,
+				localAppData: localAppData,
+			},
+			expect:  "local - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "local app data - a file is found before the tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        none,
+				localAppData: localAppData,
+				appData:      appData,
+				programData:  programData,
+			},
+			expect:  "local app data - wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "local app data - tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        none,
+				localAppData: localAppDataTree,
+				appData:      appData,
+				programData:  programData,
+			},
+			expect:  "local app data - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "app data - a file",
+			appName:     "example",
+			locations: stdLocations{
+				root:        none,
+				local:       none,
+				appData:     appData,
+				programData: programData,
+			},
+			expect:  "app data - wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "app data - tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        none,
+				localAppData: none,
+				appData:      appDataTree,
+				programData:  programData,
+			},
+			expect:  "app data - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "program data - a file",
+			appName:     "example",
+			locations: stdLocations{
+				root:         none,
+				local:        none,
+				localAppData: none,
+				appData:      none,
+				programData:  programData,
+			},
+			expect:  "program data - wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "program data - tree",
+			appName:     "example",
+			locations: stdLocations{
+				root:        none,
+				local:       none,
+				programData: programDataTree,
+			},
+			expect:  "program data - tree wanted",
+			records: []string{"2.json"},
+		}, {
+			description: "nothing found",
+			appName:     "example",
+			locations: stdLocations{
+				root:  none,
+				local: none,
+			},
+			expect:  "never wanted",
+			records: []string{"example.json"},
+		}, {
+			description: "an empty appName",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an appName with a slash",
+			appName:     "foo/bar",
+			expectedErr: ErrInvalidInput,
+		}, {
+			description: "an appName with a backslash",
+			appName:     `foo\bar`,
+			expectedErr: ErrInvalidInput,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			cfg, err := New(
+				WithDecoder(&testDecoder{extensions: []string{"json"}}),
+				winStdCfgLayout(tc.appName, tc.files, tc.locations),
+				AddFile(never, "example.json"),
+				AutoCompile(),
+			)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(err)
+
+			got, err := Unmarshal[st](cfg, Root)
+			require.NoError(err)
+			assert.Equal(st{Status: tc.expect}, got)
+			assert.Equal(tc.records, cfg.records)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// Change describes how the configuration changed between two successful
+// compilations.
+type Change struct {
+	// OldHash is the hash of the configuration prior to the compilation.
+	OldHash []byte
+
+	// NewHash is the hash of the configuration after the compilation.
+	NewHash []byte
+
+	// Keys is the sorted list of keys that were added, removed or changed.
+	// Keys are joined using the key delimiter.
+	Keys []string
+}
+
+// Subscriber provides a method that is called when the configuration changes.
+type Subscriber interface {
+	// Changed is called after a compilation results in a different
+	// configuration.  The Config is not locked during the call, so it is safe
+	// to call [Config.Unmarshal] and similar functions.
+	Changed(Change)
+}
+
+// The SubscriberFunc type is an adapter to allow the use of ordinary functions
+// as Subscribers. If f is a function with the appropriate signature,
+// SubscriberFunc(f) is a Subscriber that calls f.
+type SubscriberFunc func(Change)
+
+// Changed calls f(c)
+func (f SubscriberFunc) Changed(c Change) {
+	f(c)
+}
+
+var _ Subscriber = (*SubscriberFunc)(nil)
+
+type subscription struct {
+	id  int
+	sub Subscriber
+}
+
+// Subscribe registers a Subscriber that is called each time a compilation
+// results in a configuration that differs from the prior one.  Compilations
+// caused by [Config.Compile], [Config.With] and [Config.Watch] all notify the
+// subscribers.  Subscribers are called in the order they subscribed.
+//
+// The returned function removes the subscription.  It is safe to call more than
+// once.
+func (c *Config) Subscribe(s Subscriber) (unsubscribe func()) {
+	if s == nil {
+		return func() {}
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.nextSubID++
+	id := c.nextSubID
+	c.subscribers = append(c.subscribers, subscription{id: id, sub: s})
+
+	return func() {
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+
+		for i, item := range c.subscribers {
+			if item.id == id {
+				c.subscribers = append(c.subscribers[:i:i], c.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Subscribe registers fn to be called each time a compilation changes the
+// portion of the configuration tree found at key.  The old and new values are
+// produced the same way as [Unmarshal] produces them.  If the key is not
+// present the zero value of T is used.
+//
+// Only the values found at the key are considered; changes elsewhere in the
+// configuration, or changes that only alter where the values came from, do not
+// result in fn being called.  If the new values are unable to be unmarshaled
+// into T, fn is not called and the prior value is retained.
+//
+// The returned function removes the subscription.  It is safe to call more than
+// once.
+//
+// To subscribe to the entire configuration tree, use goschtalt.Root [Root]
+// instead of "" for more clarity.
+//
+// Valid Option Types:
+//   - [GlobalOption]
+//   - [UnmarshalOption]
+//   - [UnmarshalValueOption]
+func Subscribe[T any](c *Config, key string, fn func(old, new T), opts ...UnmarshalOption) (unsubscribe func()) {
+	if fn == nil {
+		return func() {}
+	}
+
+	ks := keySubscriber[T]{
+		c:    c,
+		key:  key,
+		opts: opts,
+		fn:   fn,
+	}
+
+	// Hold the lock until the initial value is known so any compilation that
+	// happens in the meantime is compared against it.
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	unsubscribe = c.Subscribe(&ks)
+	ks.hash, ks.value, _ = ks.load(nil)
+
+	return unsubscribe
+}
+
+// keySubscriber tracks the last known value at a key for the generic
+// Subscribe function.
+type keySubscriber[T any] struct {
+	m     sync.Mutex
+	c     *Config
+	key   string
+	opts  []UnmarshalOption
+	fn    func(old, new T)
+	hash  []byte
+	value T
+}
+
+func (ks *keySubscriber[T]) Changed(Change) {
+	ks.m.Lock()
+	defer ks.m.Unlock()
+
+	hash, value, err := ks.load(ks.hash)
+	if err != nil || bytes.Equal(hash, ks.hash) {
+		return
+	}
+
+	old := ks.value
+	ks.hash, ks.value = hash, value
+	ks.fn(old, value)
+}
+
+// load returns the hash of the subtree at the key and the unmarshaled value.
+// If the hash matches prev the value isn't unmarshaled.  A nil hash means the
+// key is not present.
+func (ks *keySubscriber[T]) load(prev []byte) (hash []byte, value T, err error) {
+	c := ks.c
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.compiledAt.Equal(time.Time{}) {
+		return nil, value, nil
+	}
+
+	obj := c.tree
+	if len(ks.key) > 0 {
+		obj, err = c.tree.Fetch(strings.Split(ks.key, c.opts.keyDelimiter), c.opts.keyDelimiter)
+		if err != nil {
+			return nil, value, nil
+		}
+	}
+
+	hash = obj.Hash()
+	if bytes.Equal(hash, prev) {
+		return hash, value, nil
+	}
+
+	err = c.unmarshal(ks.key, &value, c.tree, ks.opts...)
+	return hash, value, err
+}
+
+// publish sends any pending change to the subscribers.  It must be called
+// without the mutex held.
+func (c *Config) publish() {
+	c.mutex.Lock()
+	change := c.pending
+	c.pending = nil
+	subs := make([]subscription, len(c.subscribers))
+	copy(subs, c.subscribers)
+	c.mutex.Unlock()
+
+	if change == nil {
+		return
+	}
+
+	for _, s := range subs {
+		s.sub.Changed(*change)
+	}
+}
+
+// recordChange compares the prior configuration with the newly compiled one
+// and records the differences for the subscribers.
+func (c *Config) recordChange(prevHash []byte) {
+	keys := changedKeys(c.prevTree, c.tree, c.opts.keyDelimiter)
+	if len(keys) == 0 && bytes.Equal(prevHash, c.hash) {
+		return
+	}
+
+	c.pending = &Change{
+		OldHash: prevHash,
+		NewHash: c.hash,
+		Keys:    keys,
+	}
+}
+
+// changedKeys returns the sorted list of leaf keys that differ between the two
+// trees.
+func changedKeys(a, b meta.Object, delimiter string) []string {
+	paths := a.Diff(b).Paths()
+	if len(paths) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(paths))
+	for i, path := range paths {
+		keys[i] = strings.Join(path, delimiter)
+	}
+	sort.Strings(keys)
+	return keys
+}
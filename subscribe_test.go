@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribe(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	hashes := [][]byte{{0x01}, {0x02}, {0x03}, {0x04}}
+	var calls int
+	hasher := HasherFunc(func(any) ([]byte, error) {
+		h := hashes[calls]
+		calls++
+		return h, nil
+	})
+
+	value := "one"
+	cfg, err := New(
+		AddValueGetter("record", Root,
+			mockValueGetter{
+				f: func(string, Unmarshaler) (any, error) {
+					return map[string]any{
+						"a": value,
+						"b": "constant",
+					}, nil
+				},
+			},
+		),
+		SetHasher(hasher),
+	)
+	require.NoError(err)
+	require.NotNil(cfg)
+
+	var got []Change
+	unsubscribe := cfg.Subscribe(SubscriberFunc(func(c Change) {
+		// Make sure the config isn't locked while subscribers are called.
+		_, err := Unmarshal[string](cfg, "a")
+		assert.NoError(err)
+		got = append(got, c)
+	}))
+
+	value = "two"
+	require.NoError(cfg.Compile())
+	require.Len(got, 1)
+	assert.Equal(Change{
+		OldHash: []byte{0x01},
+		NewHash: []byte{0x02},
+		Keys:    []string{"a"},
+	}, got[0])
+
+	unsubscribe()
+	unsubscribe()
+
+	value = "three"
+	require.NoError(cfg.Compile())
+	assert.Len(got, 1)
+}
+
+func TestSubscribeNoChange(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg, err := New(AddValue("record", Root, map[string]any{"a": "b"}))
+	require.NoError(err)
+
+	var calls int
+	cfg.Subscribe(SubscriberFunc(func(Change) { calls++ }))
+	cfg.Subscribe(nil)()
+
+	require.NoError(cfg.Compile())
+	assert.Equal(0, calls)
+}
+
+func TestChangedKeys(t *testing.T) {
+	tests := []struct {
+		description string
+		a, b        meta.Object
+		want        []string
+	}{
+		{
+			description: "empty",
+		}, {
+			description: "from nothing",
+			b: meta.ObjectFromRaw(map[string]any{
+				"a": map[string]any{
+					"b": "c",
+				},
+				"d": []any{"e", "f"},
+			}),
+			want: []string{"a.b", "d.0", "d.1"},
+		}, {
+			description: "all the changes",
+			a: meta.ObjectFromRaw(map[string]any{
+				"same":    "same",
+				"removed": "gone",
+				"changed": "before",
+				"kind":    map[string]any{"a": "b"},
+				"list":    []any{"a", "b", "c"},
+			}),
+			b: meta.ObjectFromRaw(map[string]any{
+				"same":    "same",
+				"added":   "new",
+				"changed": "after",
+				"kind":    "value",
+				"list":    []any{"a", "x"},
+			}),
+			want: []string{"added", "changed", "kind", "kind.a", "list.1", "list.2", "removed"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert := assert.New(t)
+
+			got := changedKeys(tc.a, tc.b, ".")
+			assert.Equal(tc.want, got)
+		})
+	}
+}
+
+func TestSubscribeKey(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type Log struct {
+		Level string `goschtalt:"level"`
+	}
+	type call struct {
+		Old, New Log
+	}
+
+	data := map[string]any{
+		"log": map[string]any{
+			"level": "info",
+		},
+		"other": "one",
+	}
+	cfg, err := New(
+		AddValueGetter("record", Root,
+			mockValueGetter{
+				f: func(string, Unmarshaler) (any, error) {
+					return data, nil
+				},
+			},
+		),
+	)
+	require.NoError(err)
+
+	var calls []call
+	unsubscribe := Subscribe(cfg, "log", func(old, new Log) {
+		calls = append(calls, call{Old: old, New: new})
+	})
+
+	var levels []string
+	Subscribe(cfg, "log.level", func(_, new string) {
+		levels = append(levels, new)
+	})
+
+	// Changes elsewhere are ignored.
+	data["other"] = "two"
+	require.NoError(cfg.Compile())
+	assert.Empty(calls)
+
+	data["log"] = map[string]any{"level": "debug"}
+	require.NoError(cfg.Compile())
+	assert.Equal([]call{{Old: Log{Level: "info"}, New: Log{Level: "debug"}}}, calls)
+
+	// Removing the key results in the zero value.
+	delete(data, "log")
+	require.NoError(cfg.Compile())
+	assert.Equal(call{Old: Log{Level: "debug"}}, calls[1])
+	assert.Equal([]string{"debug", ""}, levels)
+
+	unsubscribe()
+	data["log"] = map[string]any{"level": "warn"}
+	require.NoError(cfg.Compile())
+	assert.Len(calls, 2)
+	assert.Equal([]string{"debug", "", "warn"}, levels)
+
+	// A value that doesn't unmarshal is skipped.
+	data["log"] = map[string]any{"level": []any{"a", "b"}}
+	require.NoError(cfg.Compile())
+	assert.Equal([]string{"debug", "", "warn"}, levels)
+
+	assert.NotNil(Subscribe[string](cfg, "log", nil))
+}
+
+func TestSubscribeKeyNotCompiled(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	cfg, err := New(
+		AddValue("record", Root, map[string]any{"a": "b"}),
+		AutoCompile(false),
+	)
+	require.NoError(err)
+
+	var got []string
+	Subscribe(cfg, Root, func(_, new map[string]string) {
+		got = append(got, new["a"])
+	})
+
+	require.NoError(cfg.Compile())
+	assert.Equal([]string{"b"}, got)
+}
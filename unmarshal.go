@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/goschtalt/goschtalt/internal/jsonschema"
 	"github.com/goschtalt/goschtalt/internal/mapstructure"
 	"github.com/goschtalt/goschtalt/internal/print"
 	"github.com/goschtalt/goschtalt/pkg/meta"
@@ -83,6 +84,53 @@ func UnmarshalFunc[T any](key string, opts ...UnmarshalOption) func(*Config) (T,
 //   - [GlobalOption]
 //   - [UnmarshalOption]
 //   - [UnmarshalValueOption]
+//
+// # Validation Rules
+//
+// Validation rules may be added to the struct tag after the field name.  The
+// rules are checked after the values are decoded and all the violations are
+// reported together, each wrapping [ErrRuleViolation] and including where the
+// offending value came from.
+//
+//   - required  - the key must be present in the configuration
+//   - nonzero   - the decoded value must not be the zero value
+//   - min=N     - the value must be at least N
+//   - max=N     - the value must be at most N
+//   - oneof=A B - the value must be one of the space separated values
+//   - pattern=R - the string value must match the regular expression R
+//
+// The min and max rules compare the length of strings, arrays, slices and
+// maps.  Durations may use limits like '5s'.  Except for required and nonzero,
+// the rules only apply to values present in the configuration.  Since the
+// struct tag options are comma separated, everything after 'pattern=' is used
+// as the pattern (commas included), so it must be the last rule.
+//
+// For example:
+//
+//	type Server struct {
+//		Port int    `goschtalt:"port,required,min=1,max=65535"`
+//		Mode string `goschtalt:",oneof=fast slow"`
+//	}
+//
+// # Default Values
+//
+// The `default` struct tag provides the value of a field when the key is
+// absent from the configuration tree.  The text is converted into booleans,
+// integers and floats as needed; other types are converted from the text by
+// the adapters like any other configuration value.  To include the defaults
+// in the configuration tree instead, see [AddDefaultsFromStruct].
+//
+// For example:
+//
+//	type Server struct {
+//		Port    int           `goschtalt:"port" default:"8080"`
+//		Timeout time.Duration `goschtalt:"timeout" default:"30s"`
+//	}
+//
+// # Errors
+//
+// If values in the configuration tree can't be decoded into the result, an
+// [UnmarshalError] listing each problem found is returned.
 func (c *Config) Unmarshal(key string, result any, opts ...UnmarshalOption) error {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
@@ -137,21 +185,9 @@ func adapterIterator(ff []adapter) func(reflect.Value, reflect.Value) (any, erro
 }
 
 func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...UnmarshalOption) error {
-	options := unmarshalOptions{
-		decoder: mapstructure.DecoderConfig{
-			Result:  result,
-			TagName: defaultTag,
-		},
-	}
-
-	full := append(c.opts.unmarshalOptions, opts...)
-	for _, opt := range full {
-		if opt != nil {
-			err := opt.unmarshalApply(&options)
-			if err != nil {
-				return err
-			}
-		}
+	options, err := c.unmarshalOptions(result, opts...)
+	if err != nil {
+		return err
 	}
 
 	options.decoder.DecodeHook = adapterIterator(options.adapters)
@@ -165,10 +201,10 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 	}
 
 	obj := tree
+	var path []string
 	if len(key) > 0 {
-		path := strings.Split(key, c.opts.keyDelimiter)
+		path = strings.Split(key, c.opts.keyDelimiter)
 
-		var err error
 		obj, err = tree.Fetch(path, c.opts.keyDelimiter)
 		if err != nil {
 			if !options.optional || !errors.Is(err, meta.ErrNotFound) {
@@ -176,6 +212,24 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 			}
 		}
 	}
+
+	// Optional values that are not present are not validated.
+	present := err == nil
+	if present {
+		if err = schemaErrors(options.schema, obj, path, c.opts.keyDelimiter); err != nil {
+			return err
+		}
+	}
+
+	// Fill in the values that are absent using the default struct tags.
+	td := tagDefaults{
+		tagName: options.decoder.TagName,
+		squash:  options.decoder.Squash,
+		mapper:  options.mapper,
+	}
+	obj = withDefaults(obj, td.build(reflect.TypeOf(result)),
+		[]meta.Origin{{File: tagDefaultsOrigin}})
+
 	raw := obj.ToRaw()
 
 	decoder, err := mapstructure.NewDecoder(&options.decoder)
@@ -183,8 +237,15 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 		return err
 	}
 	if err := decoder.Decode(raw); err != nil {
-		return err
+		return newUnmarshalError(err, obj, path, c.opts.keyDelimiter, options)
 	}
+
+	if present {
+		if err := checkRules(result, obj, path, c.opts.keyDelimiter, options); err != nil {
+			return err
+		}
+	}
+
 	if options.validator != nil {
 		if err := options.validator.Validate(result); err != nil {
 			return err
@@ -193,6 +254,29 @@ func (c *Config) unmarshal(key string, result any, tree meta.Object, opts ...Unm
 	return nil
 }
 
+// unmarshalOptions applies the default and provided options to produce the
+// options used to unmarshal into the result.
+func (c *Config) unmarshalOptions(result any, opts ...UnmarshalOption) (unmarshalOptions, error) {
+	options := unmarshalOptions{
+		decoder: mapstructure.DecoderConfig{
+			Result:  result,
+			TagName: defaultTag,
+		},
+	}
+
+	full := append(c.opts.unmarshalOptions, opts...)
+	for _, opt := range full {
+		if opt != nil {
+			err := opt.unmarshalApply(&options)
+			if err != nil {
+				return unmarshalOptions{}, err
+			}
+		}
+	}
+
+	return options, nil
+}
+
 // -- UnmarshalOption options follow -------------------------------------------
 
 // UnmarshalOption provides specific configuration for the process of producing
@@ -211,6 +295,7 @@ type unmarshalOptions struct {
 	reporters []KeymapReporter
 	decoder   mapstructure.DecoderConfig
 	validator Validator
+	schema    *jsonschema.Schema
 }
 
 // mapper is a helper function that applies the mapper function behavior
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/goschtalt/goschtalt/internal/mapstructure"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+// UnmarshalError is returned by [Config.Unmarshal] when values in the
+// configuration tree can't be decoded into the result.  Every problem found is
+// included so they may all be addressed at once instead of one at a time.
+//
+// Use errors.As() to get the UnmarshalError from the returned error.
+type UnmarshalError struct {
+	Problems []UnmarshalProblem
+}
+
+// UnmarshalProblem describes a single value that can't be decoded.
+type UnmarshalProblem struct {
+	// Key is the full key of the value in the configuration tree.
+	Key string
+
+	// Expected is the Go type the value was being decoded into, if known.
+	Expected string
+
+	// Value is the value found in the configuration tree, if present.  Secret
+	// values are redacted.
+	Value any
+
+	// Origins are where the value came from.  If the value is missing, these
+	// are the origins of the closest parent present.
+	Origins []meta.Origin
+
+	// Err describes the problem.
+	Err error
+}
+
+func (p UnmarshalProblem) String() string {
+	if p.Key == "" && p.Origins == nil {
+		return p.Err.Error()
+	}
+	return fmt.Sprintf("'%s' (%s) %s", keyString(p.Key), originsString(p.Origins), p.Err)
+}
+
+func (e *UnmarshalError) Error() string {
+	list := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		list[i] = p.String()
+	}
+	return strings.Join(list, "\n")
+}
+
+func (e *UnmarshalError) Unwrap() []error {
+	rv := make([]error, len(e.Problems))
+	for i, p := range e.Problems {
+		rv[i] = p.Err
+	}
+	return rv
+}
+
+// newUnmarshalError converts the decoding error into an UnmarshalError that
+// describes each problem using the tree the values came from.  The prefix is
+// the path to the tree.  If no specific problems are found the error is
+// returned unchanged.
+func newUnmarshalError(err error, tree meta.Object, prefix []string, delimiter string, opts unmarshalOptions) error {
+	var fields []*mapstructure.FieldError
+	var others []error
+	collectFieldErrors(err, &fields, &others)
+
+	if len(fields) == 0 {
+		return err
+	}
+
+	problems := make([]UnmarshalProblem, 0, len(fields)+len(others))
+	for _, fe := range fields {
+		path := append([]string{}, fe.Path...)
+		if fe.Missing && len(path) > 0 {
+			last := len(path) - 1
+			path[last] = opts.mapper(path[last])
+		}
+
+		p := UnmarshalProblem{
+			Key: strings.Join(append(append([]string{}, prefix...), path...), delimiter),
+			Err: fe,
+		}
+		if fe.Expected != nil {
+			p.Expected = fe.Expected.String()
+		}
+
+		obj, err := tree.Fetch(path, delimiter)
+		if err == nil {
+			p.Value = obj.ToRedacted().ToRaw()
+			p.Origins = obj.Origins
+		} else {
+			p.Origins = closestOrigins(tree, path, delimiter)
+		}
+
+		problems = append(problems, p)
+	}
+
+	for _, other := range others {
+		problems = append(problems, UnmarshalProblem{Err: other})
+	}
+
+	return &UnmarshalError{Problems: problems}
+}
+
+// collectFieldErrors walks the tree of joined errors and separates the field
+// errors from any other errors.
+func collectFieldErrors(err error, fields *[]*mapstructure.FieldError, others *[]error) {
+	if fe, ok := err.(*mapstructure.FieldError); ok { //nolint:errorlint
+		*fields = append(*fields, fe)
+		return
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		for _, e := range joined.Unwrap() {
+			collectFieldErrors(e, fields, others)
+		}
+		return
+	}
+
+	// The sentinel only adds noise.
+	if err != mapstructure.ErrDecoding { //nolint:errorlint
+		*others = append(*others, err)
+	}
+}
+
+// closestOrigins returns the origins of the closest parent of the path that
+// is present in the tree.
+func closestOrigins(tree meta.Object, path []string, delimiter string) []meta.Origin {
+	for i := len(path) - 1; i >= 0; i-- {
+		obj, err := tree.Fetch(path[:i], delimiter)
+		if err == nil {
+			return obj.Origins
+		}
+	}
+	return tree.Origins
+}
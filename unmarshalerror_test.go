@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/goschtalt/goschtalt/pkg/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalError(t *testing.T) {
+	type inner struct {
+		Count int
+	}
+	type config struct {
+		FirstName string
+		Password  string
+		Items     []inner
+		Level     int
+		Missing   bool
+	}
+
+	levelAdapter := AdaptFromCfg(AdapterFromCfgFunc(
+		func(from, to reflect.Value) (any, error) {
+			if from.Kind() != reflect.String || to.Kind() != reflect.Int || from.String() != "loud" {
+				return nil, ErrNotApplicable
+			}
+			return nil, errors.New("invalid level")
+		}), "level")
+
+	cfg, err := New(
+		ConfigIs("two_words"),
+		AddValue("base", "sub",
+			map[string]any{
+				"first_name": "Alice",
+				"items": []any{
+					map[string]any{"count": 1},
+					map[string]any{"count": "many"},
+				},
+				"level": "loud",
+				"extra": true,
+			},
+		),
+		AddValue("override", "sub.first_name", []string{"Bob"}),
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		AddBuffer("secrets.json", []byte(`{"sub": {"password((secret))": {"a": 1}}}`)),
+	)
+	require.NoError(t, err)
+
+	var result config
+	err = cfg.Unmarshal("sub", &result, Strictness(EXACT), levelAdapter)
+	require.Error(t, err)
+
+	var ue *UnmarshalError
+	require.True(t, errors.As(err, &ue))
+	assert.ErrorIs(t, err, ErrAdaptFailure)
+
+	type want struct {
+		expected string
+		value    any
+		files    []string
+	}
+	got := make(map[string]want, len(ue.Problems))
+	for _, p := range ue.Problems {
+		var files []string
+		for _, o := range p.Origins {
+			files = append(files, o.File)
+		}
+		got[p.Key] = want{
+			expected: p.Expected,
+			value:    p.Value,
+			files:    files,
+		}
+		assert.Contains(t, err.Error(), p.String())
+	}
+
+	base := []string{"base"}
+	assert.Equal(t, map[string]want{
+		"sub.first_name": {
+			expected: "string",
+			value:    []string{"Bob"},
+			files:    []string{"override"},
+		},
+		"sub.password": {
+			expected: "string",
+			value:    "REDACTED",
+			files:    []string{"secrets.json"},
+		},
+		"sub.items.1.count": {
+			expected: "int",
+			value:    "many",
+			files:    base,
+		},
+		"sub.level": {
+			expected: "int",
+			value:    "loud",
+			files:    base,
+		},
+		"sub.extra": {
+			value: true,
+			files: base,
+		},
+		"sub.missing": {
+			expected: "bool",
+			files:    base,
+		},
+	}, got)
+}
+
+func TestUnmarshalErrorUnchanged(t *testing.T) {
+	type config struct {
+		Name int `goschtalt:",squash"`
+	}
+
+	cfg, err := New(AddValue("record", Root, map[string]any{"name": 1}))
+	require.NoError(t, err)
+
+	var result config
+	err = cfg.Unmarshal(Root, &result)
+	require.Error(t, err)
+
+	var ue *UnmarshalError
+	assert.False(t, errors.As(err, &ue))
+}
+
+func TestUnmarshalProblemString(t *testing.T) {
+	errTest := errors.New("test error")
+
+	tests := []struct {
+		description string
+		in          UnmarshalProblem
+		expected    string
+	}{
+		{
+			description: "just an error",
+			in:          UnmarshalProblem{Err: errTest},
+			expected:    "test error",
+		}, {
+			description: "the root with no origins",
+			in:          UnmarshalProblem{Origins: []meta.Origin{}, Err: errTest},
+			expected:    "'<root>' (unknown) test error",
+		}, {
+			description: "a key and origin",
+			in: UnmarshalProblem{
+				Key:     "a.b",
+				Origins: []meta.Origin{{File: "file.yml", Line: 2, Col: 3}},
+				Err:     errTest,
+			},
+			expected: "'a.b' (file.yml:2[3]) test error",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.in.String())
+		})
+	}
+}
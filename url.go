@@ -0,0 +1,395 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+	"github.com/goschtalt/goschtalt/pkg/decoder"
+	"github.com/goschtalt/goschtalt/pkg/meta"
+)
+
+const defaultURLTimeout = 30 * time.Second
+
+// AddURL adds a document fetched from an http or https url as a configuration
+// record.  The recordName is used for sorting this record relative to the
+// other records.
+//
+// The document is fetched each time the configuration is compiled.  The
+// decoder is chosen based on the extension of the url path, or if there is no
+// decoder for it, the Content-Type of the response.  For the Content-Type, a
+// structured syntax suffix is used if present and any "x-" prefix is removed,
+// so "application/json", "application/vnd.app+json" and "application/x-json"
+// are all decoded with the "json" decoder.  The origin of each value is the
+// url without any user information, query or fragment, since those often
+// contain credentials.  They are removed from the messages as well.
+//
+// When the server provides an ETag or Last-Modified header, the following
+// fetches are conditional.  If the server responds with 304 Not Modified, the
+// previously decoded document is used.
+//
+// # Default
+//
+//   - The http.DefaultClient is used.  See [URLClient].
+//   - Each fetch has a timeout of 30 seconds.  See [URLTimeout].
+//   - A failed fetch is an error.  See [URLUseLastGood].
+//
+// Valid Option Types:
+//   - [URLOption]
+func AddURL(recordName, url string, opts ...URLOption) Option {
+	redacted := redactURL(url)
+	return &remote{
+		text:       print.P("AddURL", print.String(recordName), print.String(redacted), print.LiteralStringers(opts)),
+		recordName: recordName,
+		url:        url,
+		redacted:   redacted,
+		opts:       opts,
+		cache:      &remoteCache{},
+	}
+}
+
+type remote struct {
+	// The text to use when String() is called.
+	text string
+
+	// The record name.
+	recordName string
+
+	// The url of the document.
+	url string
+
+	// The url without the parts that may contain credentials, used as the
+	// origin and in messages.
+	redacted string
+
+	// Options that configure how the document is fetched.
+	opts []URLOption
+
+	// The cache is shared by all the copies of the option so it lasts
+	// between compiles.
+	cache *remoteCache
+}
+
+// remoteCache is the last document successfully fetched.
+type remoteCache struct {
+	m            sync.Mutex
+	valid        bool
+	etag         string
+	lastModified string
+	tree         meta.Object
+}
+
+func (r remote) apply(opts *options) error {
+	if len(r.recordName) == 0 {
+		return fmt.Errorf("%w: a recordName with length > 0 must be specified.", ErrInvalidInput)
+	}
+
+	u, err := url.Parse(r.url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: '%s' must be an http or https url.", ErrInvalidInput, r.redacted)
+	}
+
+	if _, err := r.options(); err != nil {
+		return err
+	}
+
+	opts.values = append(opts.values, record{
+		name:   r.recordName,
+		remote: &r,
+	})
+	return nil
+}
+
+func (_ remote) ignoreDefaults() bool {
+	return false
+}
+
+func (r remote) String() string {
+	return r.text
+}
+
+// options applies the URLOptions to the default settings.
+func (r remote) options() (urlOptions, error) {
+	cfg := urlOptions{
+		client:  http.DefaultClient,
+		timeout: defaultURLTimeout,
+	}
+
+	for _, opt := range r.opts {
+		if err := opt.urlApply(&cfg); err != nil {
+			return urlOptions{}, err
+		}
+	}
+
+	return cfg, nil
+}
+
+// toTree fetches the document and converts it into a meta.Object tree.  This
+// will happen during the compilation stage.
+func (r *remote) toTree(delimiter string, decoders *codecRegistry[decoder.Decoder]) (meta.Object, error) {
+	cfg, err := r.options()
+	if err != nil {
+		return meta.Object{}, err
+	}
+
+	r.cache.m.Lock()
+	defer r.cache.m.Unlock()
+
+	tree, err := r.fetch(cfg, delimiter, decoders)
+	if err != nil {
+		if cfg.useLastGood && r.cache.valid {
+			return r.cache.tree.Clone(), nil
+		}
+		return meta.Object{}, err
+	}
+
+	return tree, nil
+}
+
+// fetch gets the document, updating the cache if it changed.
+func (r *remote) fetch(cfg urlOptions, delimiter string, decoders *codecRegistry[decoder.Decoder]) (meta.Object, error) {
+	reqCtx := context.Background()
+	if cfg.timeout > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(reqCtx, cfg.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return meta.Object{}, r.fetchError(err)
+	}
+
+	if r.cache.valid {
+		if r.cache.etag != "" {
+			req.Header.Set("If-None-Match", r.cache.etag)
+		}
+		if r.cache.lastModified != "" {
+			req.Header.Set("If-Modified-Since", r.cache.lastModified)
+		}
+	}
+
+	resp, err := cfg.client.Do(req)
+	if err != nil {
+		return meta.Object{}, r.fetchError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && r.cache.valid {
+		return r.cache.tree.Clone(), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return meta.Object{}, fmt.Errorf("%w: '%s' responded with status %d", ErrFetchFailure, r.redacted, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return meta.Object{}, fmt.Errorf("%w: %w", ErrFetchFailure, err)
+	}
+
+	ext, dec := r.decoder(resp, decoders)
+	if dec == nil {
+		return meta.Object{}, fmt.Errorf("%w: unable to determine the format of '%s'", ErrCodecNotFound, r.redacted)
+	}
+
+	ctx := decoder.Context{
+		Filename:  r.redacted,
+		Delimiter: delimiter,
+	}
+
+	var tree meta.Object
+	err = dec.Decode(ctx, data, &tree)
+	if err != nil {
+		return meta.Object{}, fmt.Errorf("decoder error for extension '%s' processing url '%s' %w %v",
+			ext, r.redacted, ErrDecoding, err) //nolint:errorlint
+	}
+
+	r.cache.valid = true
+	r.cache.etag = resp.Header.Get("ETag")
+	r.cache.lastModified = resp.Header.Get("Last-Modified")
+	r.cache.tree = tree
+
+	return tree.Clone(), nil
+}
+
+// fetchError wraps the error as a fetch failure, replacing the url included
+// by a *url.Error with the redacted url.
+func (r *remote) fetchError(err error) error {
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return fmt.Errorf("%w: %s '%s': %w", ErrFetchFailure, ue.Op, r.redacted, ue.Err)
+	}
+	return fmt.Errorf("%w: %w", ErrFetchFailure, err)
+}
+
+// redactURL removes the user information, query and fragment from the url.
+func redactURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		// Remove the same parts from the url that can't be parsed.
+		s, _, _ = strings.Cut(s, "#")
+		s, _, _ = strings.Cut(s, "?")
+		if scheme, rest, found := strings.Cut(s, "://"); found {
+			authority, _, _ := strings.Cut(rest, "/")
+			if i := strings.LastIndex(authority, "@"); i >= 0 {
+				rest = rest[i+1:]
+			}
+			s = scheme + "://" + rest
+		}
+		return s
+	}
+
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// decoder finds the decoder based on the url extension or the Content-Type.
+func (r *remote) decoder(resp *http.Response, decoders *codecRegistry[decoder.Decoder]) (string, decoder.Decoder) {
+	var exts []string
+	if u, err := url.Parse(r.url); err == nil {
+		if ext := strings.TrimPrefix(path.Ext(u.Path), "."); ext != "" {
+			exts = append(exts, ext)
+		}
+	}
+	if ext := contentTypeToExt(resp.Header.Get("Content-Type")); ext != "" {
+		exts = append(exts, ext)
+	}
+
+	for _, ext := range exts {
+		if dec, err := decoders.find(ext); err == nil {
+			return ext, dec
+		}
+	}
+
+	return "", nil
+}
+
+// contentTypeToExt converts a Content-Type into the extension of the decoder
+// to use.
+func contentTypeToExt(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	_, sub, _ := strings.Cut(mediaType, "/")
+	if _, suffix, found := strings.Cut(sub, "+"); found {
+		sub = suffix
+	}
+
+	return strings.TrimPrefix(sub, "x-")
+}
+
+// -- URLOption options follow -------------------------------------------------
+
+// URLOption provides the means to configure how a url is fetched.
+type URLOption interface {
+	fmt.Stringer
+
+	urlApply(*urlOptions) error
+}
+
+type urlOptions struct {
+	client      *http.Client
+	timeout     time.Duration
+	useLastGood bool
+}
+
+// URLClient sets the http.Client used to fetch the url.  A non-nil client
+// must be specified.
+//
+// # Default
+//
+// The default value is http.DefaultClient.
+func URLClient(client *http.Client) URLOption {
+	return &urlClientOption{client: client}
+}
+
+type urlClientOption struct {
+	client *http.Client
+}
+
+func (u urlClientOption) urlApply(opts *urlOptions) error {
+	if u.client == nil {
+		return fmt.Errorf("%w: a non-nil http.Client must be specified.", ErrInvalidInput)
+	}
+
+	opts.client = u.client
+	return nil
+}
+
+func (u urlClientOption) String() string {
+	if u.client == nil {
+		return print.P("URLClient", print.Literal("nil"), print.SubOpt())
+	}
+	return print.P("URLClient", print.Obj(u.client), print.SubOpt())
+}
+
+// URLTimeout sets the maximum duration of each fetch.  A timeout of 0 means
+// there is no timeout beyond any set by the http.Client.
+//
+// # Default
+//
+// The default value is 30 seconds.
+func URLTimeout(timeout time.Duration) URLOption {
+	return urlTimeoutOption(timeout)
+}
+
+type urlTimeoutOption time.Duration
+
+func (u urlTimeoutOption) urlApply(opts *urlOptions) error {
+	if u < 0 {
+		return fmt.Errorf("%w: a timeout >= 0 must be specified.", ErrInvalidInput)
+	}
+
+	opts.timeout = time.Duration(u)
+	return nil
+}
+
+func (u urlTimeoutOption) String() string {
+	return print.P("URLTimeout", print.Literal(time.Duration(u).String()), print.SubOpt())
+}
+
+// URLUseLastGood specifies that the last document successfully fetched is
+// used if a later fetch fails.  If no document has been fetched yet, the
+// failure is still an error.
+//
+// The useLastGood bool value is optional & assumed to be `true` if omitted.
+// The first specified value is used if provided.  A value of `false` disables
+// the option.
+//
+// # Default
+//
+// A failed fetch is an error.
+func URLUseLastGood(useLastGood ...bool) URLOption {
+	useLastGood = append(useLastGood, true)
+	return urlUseLastGoodOption(useLastGood[0])
+}
+
+type urlUseLastGoodOption bool
+
+func (u urlUseLastGoodOption) urlApply(opts *urlOptions) error {
+	opts.useLastGood = bool(u)
+	return nil
+}
+
+func (u urlUseLastGoodOption) String() string {
+	return print.P("URLUseLastGood", print.BoolSilentTrue(bool(u)), print.SubOpt())
+}
@@ -114,7 +114,14 @@ func (v value) toTree(delimiter string, u Unmarshaler, defaultOpts ...ValueOptio
 		}
 	}
 
-	data, err := v.getter.Get(v.recordName, u)
+	getter := v.getter
+	if g, ok := getter.(tagDefaultsGetter); ok {
+		// The struct tags used depend on the options.
+		g.tagName = cfg.tagName
+		getter = g
+	}
+
+	data, err := getter.Get(v.recordName, u)
 	if err != nil {
 		return meta.Object{}, err
 	}
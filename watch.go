@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io/fs"
+	"reflect"
+	"time"
+
+	"github.com/goschtalt/goschtalt/internal/print"
+)
+
+// defaultPollInterval is the interval used to check for changes when no
+// Notifier has been specified.
+const defaultPollInterval = 5 * time.Second
+
+// Notifier provides the methods needed to learn when the files behind the
+// configuration may have changed.
+type Notifier interface {
+	// Notify is called by [Config.Watch] with the list of filesystem roots
+	// used by the configuration.  Each time the files may have changed the
+	// changed function should be called.  It is fine to call changed when
+	// nothing has changed; the files are examined before any recompilation
+	// takes place.
+	//
+	// Notify must block until the context is done and then return nil, or
+	// return an error if it is unable to continue.
+	Notify(ctx context.Context, roots []fs.FS, changed func()) error
+}
+
+// The NotifierFunc type is an adapter to allow the use of ordinary functions
+// as Notifiers. If f is a function with the appropriate signature,
+// NotifierFunc(f) is a Notifier that calls f.
+type NotifierFunc func(context.Context, []fs.FS, func()) error
+
+// Notify calls f(ctx, roots, changed)
+func (f NotifierFunc) Notify(ctx context.Context, roots []fs.FS, changed func()) error {
+	return f(ctx, roots, changed)
+}
+
+var _ Notifier = (*NotifierFunc)(nil)
+
+// PollNotifier provides a Notifier that reports a possible change each time
+// the interval elapses.  An interval less than or equal to 0 uses the default
+// interval of 5 seconds.
+func PollNotifier(interval time.Duration) Notifier {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	return poller(interval)
+}
+
+type poller time.Duration
+
+func (p poller) Notify(ctx context.Context, _ []fs.FS, changed func()) error {
+	ticker := time.NewTicker(time.Duration(p))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			changed()
+		}
+	}
+}
+
+func (p poller) String() string {
+	return print.P("PollNotifier", print.Literal(time.Duration(p).String()))
+}
+
+// SetNotifier provides a way to specify how [Config.Watch] learns that the
+// files behind the configuration may have changed.  If the provided Notifier
+// is nil, then the files are polled every 5 seconds.
+//
+// # Default
+//
+// The files are polled every 5 seconds.
+func SetNotifier(n Notifier) Option {
+	return &notifierOption{
+		notifier: n,
+	}
+}
+
+type notifierOption struct {
+	notifier Notifier
+}
+
+func (n notifierOption) apply(opts *options) error {
+	opts.notifier = n.notifier
+	return nil
+}
+
+func (_ notifierOption) ignoreDefaults() bool { return false }
+func (n notifierOption) String() string {
+	return print.P("SetNotifier", print.Obj(n.notifier))
+}
+
+// Watch monitors the files added via [AddFile], [AddFiles], [AddTree],
+// [AddDir] and similar options and recompiles the configuration when the
+// contents of the files change.  Any subscribers registered via
+// [Config.Subscribe] are told about the changes.
+//
+// Watch blocks until the context is done or the [Notifier] returns an error.
+// Once the context is done, nil is returned.  Typically Watch is run in its own
+// goroutine:
+//
+//	go cfg.Watch(ctx)
+//
+// Compilation errors encountered while watching do not stop the watch.  The
+// last successfully compiled configuration remains in effect and the errors
+// are available via [Config.Explain].
+//
+// The roots passed to the Notifier are determined when Watch is called.  Use
+// [SetNotifier] to change how changes are detected.
+func (c *Config) Watch(ctx context.Context) error {
+	c.mutex.Lock()
+	notifier := c.opts.notifier
+	roots := c.opts.roots()
+	c.mutex.Unlock()
+
+	if notifier == nil {
+		notifier = PollNotifier(defaultPollInterval)
+	}
+
+	caches := make(map[int]includeCache)
+	last, err := c.fingerprint(caches)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	pending := make(chan struct{}, 1)
+	done := make(chan error, 1)
+	go func() {
+		done <- notifier.Notify(ctx, roots, func() {
+			select {
+			case pending <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-done:
+			return err
+		case <-pending:
+			current, err := c.fingerprint(caches)
+			if err == nil && !bytes.Equal(last, current) {
+				last = current
+
+				// Errors are recorded in the explanation; keep watching.
+				_ = c.Compile()
+			}
+
+			if c.polled != nil {
+				c.polled()
+			}
+		}
+	}
+}
+
+// fingerprint calculates a hash of the names and contents of all the files
+// currently described by the filegroups.  The caches of the included files
+// are kept for each filegroup between calls.
+func (c *Config) fingerprint(caches map[int]includeCache) ([]byte, error) {
+	c.mutex.Lock()
+	groups := c.opts.filegroups
+	delimiter := c.opts.keyDelimiter
+	decoders := c.opts.decoders
+	c.mutex.Unlock()
+
+	h := sha256.New()
+	for i, grp := range groups {
+		if caches[i] == nil {
+			caches[i] = make(includeCache)
+		}
+
+		fmt.Fprintf(h, "group:%d:", i)
+		if err := grp.fingerprint(h, caches[i], delimiter, decoders); err != nil {
+			return nil, err
+		}
+	}
+
+	return h.Sum(nil), nil
+}
+
+// roots returns the unique list of filesystems used by the filegroups.
+func (opts *options) roots() []fs.FS {
+	roots := make([]fs.FS, 0, len(opts.filegroups))
+	for _, grp := range opts.filegroups {
+		if grp.fs == nil || containsFS(roots, grp.fs) {
+			continue
+		}
+		roots = append(roots, grp.fs)
+	}
+	return roots
+}
+
+// containsFS returns if the filesystem is already in the list.  Filesystems
+// that are not comparable are never considered present.
+func containsFS(list []fs.FS, f fs.FS) bool {
+	if !reflect.TypeOf(f).Comparable() {
+		return false
+	}
+
+	for _, item := range list {
+		if reflect.TypeOf(item) == reflect.TypeOf(f) && item == f {
+			return true
+		}
+	}
+	return false
+}
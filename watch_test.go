@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: 2026 Weston Schmidt <weston_schmidt@alumni.purdue.edu>
+// SPDX-License-Identifier: Apache-2.0
+
+package goschtalt
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// manualNotifier lets the test decide when a change may have happened.
+type manualNotifier struct {
+	ready   chan []fs.FS
+	trigger chan struct{}
+	err     error
+}
+
+func newManualNotifier() *manualNotifier {
+	return &manualNotifier{
+		ready:   make(chan []fs.FS, 1),
+		trigger: make(chan struct{}),
+	}
+}
+
+func (m *manualNotifier) Notify(ctx context.Context, roots []fs.FS, changed func()) error {
+	m.ready <- roots
+	if m.err != nil {
+		return m.err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-m.trigger:
+			changed()
+		}
+	}
+}
+
+func TestWatch(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	type st struct {
+		Hello string
+		Other string
+	}
+
+	files := fstest.MapFS{
+		"conf.d/1.json": &fstest.MapFile{
+			Data: []byte(`{"Hello":"World"}`),
+			Mode: 0755,
+		},
+	}
+
+	notifier := newManualNotifier()
+	cfg, err := New(
+		AddTree(files, "conf.d"),
+		AddFiles(files, "missing/*.json"),
+		WithDecoder(&testDecoder{extensions: []string{"json"}}),
+		SetNotifier(notifier),
+	)
+	require.NoError(err)
+
+	changes := make(chan Change, 10)
+	cfg.Subscribe(SubscriberFunc(func(c Change) {
+		changes <- c
+	}))
+
+	// Each trigger is only sent after the prior check completes so none of
+	// them are combined.
+	polled := make(chan struct{}, 10)
+	cfg.polled = func() {
+		polled <- struct{}{}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- cfg.Watch(ctx)
+	}()
+
+	roots := <-notifier.ready
+	// A MapFS is not comparable, so each group reports its own root.
+	assert.Len(roots, 2)
+
+	wait := func() Change {
+		select {
+		case c := <-changes:
+			return c
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for a change")
+		}
+		return Change{}
+	}
+
+	waitPoll := func() {
+		select {
+		case <-polled:
+		case <-time.After(5 * time.Second):
+			require.FailNow("timed out waiting for the check for changes")
+		}
+	}
+
+	files["conf.d/2.json"] = &fstest.MapFile{
+		Data: []byte(`{"Other":"thing"}`),
+		Mode: 0755,
+	}
+	notifier.trigger <- struct{}{}
+	assert.Equal([]string{"Other"}, wait().Keys)
+	waitPoll()
+
+	got, err := Unmarshal[st](cfg, Root)
+	require.NoError(err)
+	assert.Equal(st{Hello: "World", Other: "thing"}, got)
+
+	files["conf.d/1.json"] = &fstest.MapFile{
+		Data: []byte(`{"Hello":"Mr. Blue Sky"}`),
+		Mode: 0755,
+	}
+	notifier.trigger <- struct{}{}
+	assert.Equal([]string{"Hello"}, wait().Keys)
+	waitPoll()
+
+	// A file that fails to compile leaves the prior configuration in place.
+	files["conf.d/1.json"] = &fstest.MapFile{
+		Data: []byte(`invalid`),
+		Mode: 0755,
+	}
+	notifier.trigger <- struct{}{}
+	waitPoll()
+	assert.NotEmpty(cfg.Explain().CompileErrors)
+
+	got, err = Unmarshal[st](cfg, Root)
+	require.NoError(err)
+	assert.Equal(st{Hello: "Mr. Blue Sky", Other: "thing"}, got)
+
+	// Nothing changed, so no compile should happen.
+	compiledAt := cfg.Explain().CompileFinishedAt
+	notifier.trigger <- struct{}{}
+	waitPoll()
+	assert.Empty(changes)
+	assert.Equal(compiledAt, cfg.Explain().CompileFinishedAt)
+
+	cancel()
+	assert.NoError(<-done)
+	assert.Empty(changes)
+}
+
+func TestWatchNotifierError(t *testing.T) {
+	testErr := errors.New("test err")
+
+	notifier := newManualNotifier()
+	notifier.err = testErr
+
+	cfg, err := New(SetNotifier(notifier))
+	require.NoError(t, err)
+
+	err = cfg.Watch(context.Background())
+	assert.ErrorIs(t, err, testErr)
+}
+
+func TestWatchInvalidPath(t *testing.T) {
+	cfg, err := New(
+		AddFiles(fstest.MapFS{}, "../invalid"),
+		AutoCompile(false),
+	)
+	require.NoError(t, err)
+
+	err = cfg.Watch(context.Background())
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
+
+func TestPollNotifier(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(poller(defaultPollInterval), PollNotifier(0))
+	assert.Equal("PollNotifier( 5s )", PollNotifier(-1).(poller).String())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	count := make(chan struct{}, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- PollNotifier(time.Millisecond).Notify(ctx, nil, func() {
+			select {
+			case count <- struct{}{}:
+			default:
+			}
+		})
+	}()
+
+	<-count
+	cancel()
+	assert.NoError(<-done)
+}
+
+func TestSetNotifier(t *testing.T) {
+	assert := assert.New(t)
+
+	n := PollNotifier(time.Second)
+	opt := SetNotifier(n)
+
+	var opts options
+	assert.NoError(opt.apply(&opts))
+	assert.Equal(n, opts.notifier)
+	assert.False(opt.ignoreDefaults())
+	assert.Equal("SetNotifier( goschtalt.poller )", opt.String())
+}
+
+func TestNotifierFunc(t *testing.T) {
+	called := false
+	f := NotifierFunc(func(context.Context, []fs.FS, func()) error {
+		called = true
+		return nil
+	})
+
+	assert.NoError(t, f.Notify(context.Background(), nil, nil))
+	assert.True(t, called)
+}